@@ -0,0 +1,93 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+// CredentialProvider retrieves the token authenticating the requests sent to the Conductor server. The tokens it
+// returns are cached by the CachedTokenManager.
+type CredentialProvider interface {
+	// RetrieveToken returns a token along with the time after which it must be retrieved again. A zero time means it
+	// is read from the exp claim of the token, or defaults to TokenExpiration.DefaultExpiration.
+	RetrieveToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, time.Time, error)
+}
+
+// KeySecretCredentialProvider exchanges an application key id and secret for a token with the Conductor server.
+type KeySecretCredentialProvider struct {
+	credentials settings.AuthenticationSettings
+}
+
+func NewKeySecretCredentialProvider(credentials settings.AuthenticationSettings) *KeySecretCredentialProvider {
+	return &KeySecretCredentialProvider{
+		credentials: credentials,
+	}
+}
+
+func (p *KeySecretCredentialProvider) RetrieveToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, time.Time, error) {
+	if p.credentials.IsEmpty() {
+		return "", time.Time{}, errors.New("no key id and secret set")
+	}
+	token, response, err := GetToken(p.credentials, httpSettings, httpClient)
+	if err != nil {
+		if response != nil {
+			err = fmt.Errorf("token request failed with status %s: %w", response.Status, err)
+		}
+		return "", time.Time{}, err
+	}
+	return token.Token, time.Time{}, nil
+}
+
+// StaticCredentialProvider returns a token obtained out of the SDK, such as a long-lived bearer token.
+type StaticCredentialProvider struct {
+	token string
+}
+
+func NewStaticCredentialProvider(token string) *StaticCredentialProvider {
+	return &StaticCredentialProvider{
+		token: token,
+	}
+}
+
+func (p *StaticCredentialProvider) RetrieveToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, time.Time, error) {
+	if p.token == "" {
+		return "", time.Time{}, errors.New("no token set")
+	}
+	return p.token, time.Time{}, nil
+}
+
+// ChainCredentialProvider returns the token of the first of its providers which retrieves one, trying them in order.
+type ChainCredentialProvider struct {
+	providers []CredentialProvider
+}
+
+func NewChainCredentialProvider(providers ...CredentialProvider) *ChainCredentialProvider {
+	return &ChainCredentialProvider{
+		providers: providers,
+	}
+}
+
+func (p *ChainCredentialProvider) RetrieveToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, time.Time, error) {
+	var errs []error
+	for _, provider := range p.providers {
+		token, expiry, err := provider.RetrieveToken(httpSettings, httpClient)
+		if err == nil {
+			return token, expiry, nil
+		}
+		errs = append(errs, err)
+	}
+	return "", time.Time{}, fmt.Errorf("no credential provider retrieved a token: %w", errors.Join(errs...))
+}
@@ -0,0 +1,74 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package authentication
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+const defaultTokenFileReloadInterval = time.Minute
+
+// FileCredentialProvider reads the token from a file, such as a projected Kubernetes service account token. The file
+// is checked for changes every ReloadInterval, and read again when it changed.
+type FileCredentialProvider struct {
+	path           string
+	reloadInterval time.Duration
+
+	mutex   sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentialProvider returns a FileCredentialProvider checking the file for changes every minute.
+func NewFileCredentialProvider(path string) *FileCredentialProvider {
+	return NewFileCredentialProviderWithReloadInterval(path, defaultTokenFileReloadInterval)
+}
+
+func NewFileCredentialProviderWithReloadInterval(path string, reloadInterval time.Duration) *FileCredentialProvider {
+	return &FileCredentialProvider{
+		path:           path,
+		reloadInterval: reloadInterval,
+	}
+}
+
+func (p *FileCredentialProvider) RetrieveToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, time.Time, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read token file: %w", err)
+	}
+	if p.token == "" || !info.ModTime().Equal(p.modTime) || info.Size() != p.size {
+		content, err := os.ReadFile(p.path)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return "", time.Time{}, fmt.Errorf("token file %s is empty", p.path)
+		}
+		log.Debugw("Loaded token file", "path", p.path)
+		p.token, p.modTime, p.size = token, info.ModTime(), info.Size()
+	}
+	reloadAt := time.Now().Add(p.reloadInterval)
+	if expiry, ok := GetTokenExpiry(p.token); ok && expiry.Before(reloadAt) {
+		reloadAt = expiry
+	}
+	return p.token, reloadAt, nil
+}
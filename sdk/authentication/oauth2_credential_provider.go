@@ -0,0 +1,94 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package authentication
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+// OAuth2CredentialProvider obtains an access token from an OAuth2 issuer with the client credentials grant. The client
+// id and secret are sent with HTTP basic authentication.
+type OAuth2CredentialProvider struct {
+	// TokenURL is the token endpoint of the issuer.
+	TokenURL     string
+	ClientId     string
+	ClientSecret string
+	// Scopes are the scopes requested for the access token.
+	Scopes []string
+	// EndpointParams are additional parameters of the token request, such as an audience.
+	EndpointParams url.Values
+}
+
+func NewOAuth2CredentialProvider(tokenURL string, clientId string, clientSecret string, scopes ...string) *OAuth2CredentialProvider {
+	return &OAuth2CredentialProvider{
+		TokenURL:     tokenURL,
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+	}
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (p *OAuth2CredentialProvider) RetrieveToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, time.Time, error) {
+	form := url.Values{}
+	for key, values := range p.EndpointParams {
+		form[key] = values
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+	request, err := http.NewRequest(http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(p.ClientId), url.QueryEscape(p.ClientSecret))
+	requestTime := time.Now()
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", time.Time{}, fmt.Errorf("token request failed with status %s: %s", response.Status, body)
+	}
+	var tokenResponse oauth2TokenResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return "", time.Time{}, errors.New("token response holds no access token")
+	}
+	var expiry time.Time
+	if tokenResponse.ExpiresIn > 0 {
+		expiry = requestTime.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return tokenResponse.AccessToken, expiry, nil
+}
@@ -11,16 +11,23 @@ package authentication
 
 import "time"
 
+const defaultTokenRefreshBefore = 5 * time.Minute
+
 // sets the default expiration time for each generated token and a cleanupInterval to old delete entries
 type TokenExpiration struct {
+	// DefaultExpiration is the expiration of tokens whose expiry can't be read from their exp claim.
 	DefaultExpiration time.Duration
 	CleanupInterval   time.Duration
+	// RefreshBefore is how long before its expiry a token in use is refreshed in the background, but no earlier than
+	// halfway through its lifetime. Zero disables background refreshes.
+	RefreshBefore time.Duration
 }
 
 func NewTokenExpiration(defaultExpiration time.Duration, cleanupInterval time.Duration) *TokenExpiration {
 	return &TokenExpiration{
 		DefaultExpiration: defaultExpiration,
 		CleanupInterval:   cleanupInterval,
+		RefreshBefore:     defaultTokenRefreshBefore,
 	}
 }
 
@@ -28,5 +35,6 @@ func NewDefaultTokenExpiration() *TokenExpiration {
 	return &TokenExpiration{
 		DefaultExpiration: 30 * time.Minute,
 		CleanupInterval:   2 * time.Hour,
+		RefreshBefore:     defaultTokenRefreshBefore,
 	}
 }
@@ -27,9 +27,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
-	log "github.com/sirupsen/logrus"
 )
 
 var (
@@ -288,7 +288,7 @@ func getDecompressedBody(response *http.Response) ([]byte, error) {
 	case "gzip":
 		reader, err = gzip.NewReader(response.Body)
 		if err != nil {
-			log.Error("Unable to decompress the response ", err.Error())
+			log.Errorw("Unable to decompress the response", log.ErrorKey, err)
 			if err == io.EOF {
 				return nil, nil
 			}
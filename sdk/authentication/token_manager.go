@@ -12,10 +12,12 @@ package authentication
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
 	"github.com/patrickmn/go-cache"
-	log "github.com/sirupsen/logrus"
 )
 
 const (
@@ -26,31 +28,69 @@ type TokenManager interface {
 	RefreshToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, error)
 }
 
+// TokenInvalidator is implemented by the TokenManagers whose cached token can be discarded, so that the next call to
+// RefreshToken fetches a new one. It is used when the server rejects a token before its expiry.
+type TokenInvalidator interface {
+	// InvalidateToken discards the token, unless it has already been replaced.
+	InvalidateToken(token string)
+}
+
+// CachedTokenManager caches the token retrieved by its CredentialProvider until it expires. A token in use is refreshed
+// in the background shortly before it expires, so that requests don't wait for it.
 type CachedTokenManager struct {
-	mutex       sync.RWMutex
-	credentials settings.AuthenticationSettings
-	database    cache.Cache
+	mutex        sync.RWMutex
+	provider     CredentialProvider
+	database     cache.Cache
+	expiration   TokenExpiration
+	refreshTimer *time.Timer
+	// used is whether the token was requested since it was last refreshed
+	used atomic.Bool
 }
 
+// NewTokenManager returns a CachedTokenManager exchanging the key id and secret for tokens.
 func NewTokenManager(credentials settings.AuthenticationSettings, tokenExpiration *TokenExpiration) TokenManager {
+	return NewTokenManagerWithCredentialProvider(NewKeySecretCredentialProvider(credentials), tokenExpiration)
+}
+
+// NewTokenManagerWithCredentialProvider returns a CachedTokenManager caching the tokens retrieved by the provider.
+func NewTokenManagerWithCredentialProvider(provider CredentialProvider, tokenExpiration *TokenExpiration) TokenManager {
 	if tokenExpiration == nil {
 		tokenExpiration = NewDefaultTokenExpiration()
 	}
 	return &CachedTokenManager{
-		credentials: credentials,
+		provider: provider,
 		database: *cache.New(
 			tokenExpiration.DefaultExpiration,
 			tokenExpiration.CleanupInterval,
 		),
+		expiration: *tokenExpiration,
 	}
 }
 
 func (t *CachedTokenManager) RefreshToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, error) {
 	token := t.getTokenIfCached()
-	if token != "" {
-		return token, nil
+	if token == "" {
+		var err error
+		token, err = t.refreshToken(httpSettings, httpClient)
+		if err != nil {
+			return "", err
+		}
+	}
+	t.used.Store(true)
+	return token, nil
+}
+
+func (t *CachedTokenManager) InvalidateToken(token string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if cached, found := t.database.Get(tokenKey); !found || cached.(string) != token {
+		return
+	}
+	log.Debugw("Invalidating authentication token")
+	t.database.Delete(tokenKey)
+	if t.refreshTimer != nil {
+		t.refreshTimer.Stop()
 	}
-	return t.refreshToken(httpSettings, httpClient)
 }
 
 func (t *CachedTokenManager) getTokenIfCached() string {
@@ -66,18 +106,65 @@ func (t *CachedTokenManager) getTokenIfCached() string {
 func (t *CachedTokenManager) refreshToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, error) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
-	log.Debug("Refreshing authentication token")
-	token, response, err := GetToken(t.credentials, httpSettings, httpClient)
+	if token, found := t.database.Get(tokenKey); found {
+		// refreshed while waiting for the lock
+		return token.(string), nil
+	}
+	token, err := t.fetchToken(httpSettings, httpClient)
 	if err != nil {
-		log.Warning(
-			"Failed to refresh authentication token",
-			", response: ", response,
-			", error: ", err,
-		)
 		t.database.Delete(tokenKey)
 		return "", err
 	}
-	log.Debug("Refreshed authentication token")
-	t.database.Set(tokenKey, token.Token, cache.DefaultExpiration)
-	return token.Token, nil
+	return token, nil
+}
+
+// refreshInBackground replaces the cached token before it expires, if it was used since it was last refreshed. The
+// cached token is kept when the refresh fails, for as long as it is valid.
+func (t *CachedTokenManager) refreshInBackground(httpSettings *settings.HttpSettings, httpClient *http.Client) {
+	if !t.used.Load() {
+		log.Debugw("Authentication token not used since last refresh, letting it expire")
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.fetchToken(httpSettings, httpClient)
+}
+
+// fetchToken retrieves a new token from the provider, caches it until its expiry and schedules its refresh. It must be
+// called with the lock held.
+func (t *CachedTokenManager) fetchToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, error) {
+	log.Debugw("Refreshing authentication token")
+	token, expiry, err := t.provider.RetrieveToken(httpSettings, httpClient)
+	if err != nil {
+		log.Warnw("Failed to refresh authentication token", log.ErrorKey, err)
+		return "", err
+	}
+	if expiry.IsZero() {
+		expiry, _ = GetTokenExpiry(token)
+	}
+	lifetime := t.expiration.DefaultExpiration
+	if !expiry.IsZero() && time.Until(expiry) > 0 {
+		lifetime = time.Until(expiry)
+	}
+	log.Debugw("Refreshed authentication token", "expiresIn", lifetime)
+	t.database.Set(tokenKey, token, lifetime)
+	t.scheduleRefresh(lifetime, httpSettings, httpClient)
+	return token, nil
+}
+
+func (t *CachedTokenManager) scheduleRefresh(lifetime time.Duration, httpSettings *settings.HttpSettings, httpClient *http.Client) {
+	if t.refreshTimer != nil {
+		t.refreshTimer.Stop()
+	}
+	if t.expiration.RefreshBefore <= 0 || lifetime <= 0 {
+		return
+	}
+	delay := lifetime - t.expiration.RefreshBefore
+	if delay < lifetime/2 {
+		delay = lifetime / 2
+	}
+	t.used.Store(false)
+	t.refreshTimer = time.AfterFunc(delay, func() {
+		t.refreshInBackground(httpSettings, httpClient)
+	})
 }
@@ -20,19 +20,23 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"io"
 	"mime/multipart"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/conductor-sdk/conductor-go/sdk/authentication"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -49,6 +53,9 @@ var (
 
 type APIClient struct {
 	httpRequester *HttpRequester
+
+	retryPolicyMutex sync.RWMutex
+	retryPolicy      *RetryPolicy
 }
 
 func NewAPIClient(
@@ -109,48 +116,170 @@ func NewAPIClientWithTokenManager(
 	)
 }
 
+// NewAPIClientWithCredentialProvider returns an APIClient authenticating its requests with the tokens retrieved by the
+// credential provider.
+func NewAPIClientWithCredentialProvider(
+	httpSettings *settings.HttpSettings,
+	credentialProvider authentication.CredentialProvider,
+) *APIClient {
+	return newAPIClient(
+		nil,
+		httpSettings,
+		nil,
+		authentication.NewTokenManagerWithCredentialProvider(credentialProvider, nil),
+	)
+}
+
 func newAPIClient(authenticationSettings *settings.AuthenticationSettings, httpSettings *settings.HttpSettings, tokenExpiration *authentication.TokenExpiration, tokenManager authentication.TokenManager) *APIClient {
 	if httpSettings == nil {
 		httpSettings = settings.NewHttpDefaultSettings()
 	}
-	var httpTimeout = 30 * time.Second // Set default value once
+	return &APIClient{
+		httpRequester: NewHttpRequester(
+			authenticationSettings, httpSettings, newHttpClient(httpSettings), tokenExpiration, tokenManager,
+		),
+	}
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests. A nil policy disables retries, which is the default:
+// workers already back off between polls, and their updates are retried by the TaskRunner. It can be overridden for
+// a single call with WithRetryPolicy.
+func (c *APIClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicyMutex.Lock()
+	defer c.retryPolicyMutex.Unlock()
+	c.retryPolicy = policy
+}
 
-	timeoutStr := os.Getenv(CONDUCTOR_CLIENT_HTTP_TIMEOUT)
-	if timeoutStr != "" {
-		// Only try to parse if the environment variable is actually set
-		if timeoutInt, err := strconv.Atoi(timeoutStr); err == nil {
-			httpTimeout = time.Duration(timeoutInt) * time.Second
+// GetRetryPolicy returns the policy used to retry failed requests, nil when requests are not retried.
+func (c *APIClient) GetRetryPolicy() *RetryPolicy {
+	c.retryPolicyMutex.RLock()
+	defer c.retryPolicyMutex.RUnlock()
+	return c.retryPolicy
+}
+
+func (c *APIClient) getRetryPolicy(ctx context.Context) *RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyContextKey{}).(*RetryPolicy); ok {
+		return policy
+	}
+	return c.GetRetryPolicy()
+}
+
+// callAPI do the request, retrying it according to the retry policy. A request whose token is rejected is sent once
+// more with a new token.
+func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	if idempotencyKey := idempotencyKeyFromContext(ctx); idempotencyKey != "" && request.Header.Get(IdempotencyKeyHeader) == "" {
+		request.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
+	policy := c.getRetryPolicy(ctx)
+	start := time.Now()
+	reauthorized := false
+	for attempt, retries := 0, 0; ; attempt++ {
+		response, err := c.doCallAPI(request, attempt)
+		if err == nil && !reauthorized && c.httpRequester.isTokenRejected(request, response) {
+			reauthorized = true
+			retry, reauthorizeErr := c.httpRequester.reauthorize(request)
+			if reauthorizeErr != nil {
+				log.Warnw("Failed to renew rejected authentication token", "path", request.URL.Path, log.ErrorKey, reauthorizeErr)
+				return response, err
+			}
+			log.Debugw("Authentication token rejected, retrying with a new one", "path", request.URL.Path)
+			discardResponse(response)
+			request = retry
+			continue
+		}
+		if !policy.isRetryable(request, response, err) {
+			return response, err
+		}
+		retries++
+		backoff, ok := policy.backoff(retries, response)
+		if !ok {
+			return response, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(backoff).After(deadline) {
+			return response, err
+		}
+		logRetry(request, response, err, retries, backoff, time.Since(start))
+		discardResponse(response)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		request, err = rewindRequest(request)
+		if err != nil {
+			return nil, err
 		}
-		// If parsing fails, we'll keep the default value
 	}
+}
 
-	baseDialer := &net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
+// doCallAPI do a single attempt of the request, in a span propagated to the server.
+func (c *APIClient) doCallAPI(request *http.Request, attempt int) (*http.Response, error) {
+	ctx, span := tracing.Tracer().Start(
+		request.Context(),
+		"HTTP "+request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", request.Method),
+			attribute.String("url.path", request.URL.Path),
+			attribute.String("server.address", request.URL.Host),
+		),
+	)
+	if attempt > 0 {
+		span.SetAttributes(attribute.Int("http.request.resend_count", attempt))
 	}
-	netTransport := &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
-		DialContext:         baseDialer.DialContext,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
-		DisableCompression:  false,
+	request = request.WithContext(ctx)
+	tracing.InjectIntoHeaders(ctx, propagation.HeaderCarrier(request.Header))
+	response, err := c.httpRequester.httpClient.Do(request)
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+		if response.StatusCode >= 400 {
+			span.SetStatus(codes.Error, response.Status)
+		}
 	}
-	client := http.Client{
-		Transport:     netTransport,
-		CheckRedirect: nil,
-		Jar:           nil,
-		Timeout:       httpTimeout,
+	tracing.EndSpan(span, err)
+	return response, err
+}
+
+// discardResponse closes the response, after reading what is left of a small body so that the connection is reused.
+func discardResponse(response *http.Response) {
+	if response == nil {
+		return
 	}
-	return &APIClient{
-		httpRequester: NewHttpRequester(
-			authenticationSettings, httpSettings, &client, tokenExpiration, tokenManager,
-		),
+	io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	response.Body.Close()
+}
+
+// rewindRequest returns a copy of the request with a fresh body, to be sent again.
+func rewindRequest(request *http.Request) (*http.Request, error) {
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return nil, errors.New("the request body can't be sent again")
 	}
+	retry := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
 }
 
-// callAPI do the request.
-func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
-	return c.httpRequester.httpClient.Do(request)
+func logRetry(request *http.Request, response *http.Response, err error, attempt int, backoff time.Duration, elapsed time.Duration) {
+	keysAndValues := []interface{}{
+		"method", request.Method,
+		"path", request.URL.Path,
+		"attempt", attempt,
+		"backoff", backoff,
+		"elapsed", elapsed,
+	}
+	if err != nil {
+		keysAndValues = append(keysAndValues, log.ErrorKey, err)
+	} else {
+		keysAndValues = append(keysAndValues, "statusCode", response.StatusCode)
+	}
+	log.Debugw("Retrying request", keysAndValues...)
 }
 
 func (c *APIClient) decode(v interface{}, b []byte, contentType string) (err error) {
@@ -328,7 +457,7 @@ func getDecompressedBody(response *http.Response) ([]byte, error) {
 	case "gzip":
 		reader, err = gzip.NewReader(response.Body)
 		if err != nil {
-			log.Error("Unable to decompress the response ", err.Error())
+			log.Errorw("Unable to decompress the response", log.ErrorKey, err)
 			if err == io.EOF {
 				return nil, nil
 			}
@@ -406,7 +535,7 @@ func (c *APIClient) executeCall(ctx context.Context, method, path string, queryP
 	}
 
 	// Handle error response - create GenericSwaggerError with status code
-	newErr := NewGenericSwaggerError(respBody, string(respBody), nil, resp.StatusCode)
+	newErr := newResponseError(resp, respBody, string(respBody))
 	return resp, newErr
 }
 
@@ -0,0 +1,48 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import "net/http"
+
+// Sentinel errors matched by the errors returned for the responses of the server with the corresponding status code.
+var (
+	ErrBadRequest      error = newStatusCodeError("bad request", http.StatusBadRequest, http.StatusBadRequest)
+	ErrUnauthorized    error = newStatusCodeError("unauthorized", http.StatusUnauthorized, http.StatusUnauthorized)
+	ErrForbidden       error = newStatusCodeError("forbidden", http.StatusForbidden, http.StatusForbidden)
+	ErrNotFound        error = newStatusCodeError("not found", http.StatusNotFound, http.StatusNotFound)
+	ErrConflict        error = newStatusCodeError("conflict", http.StatusConflict, http.StatusConflict)
+	ErrPayloadTooLarge error = newStatusCodeError("payload too large", http.StatusRequestEntityTooLarge, http.StatusRequestEntityTooLarge)
+	ErrRateLimited     error = newStatusCodeError("rate limited", http.StatusTooManyRequests, http.StatusTooManyRequests)
+	// ErrServerError is matched by every status code from 500 to 599.
+	ErrServerError        error = newStatusCodeError("server error", http.StatusInternalServerError, 599)
+	ErrServiceUnavailable error = newStatusCodeError("service unavailable", http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+)
+
+type statusCodeError struct {
+	message       string
+	minStatusCode int
+	maxStatusCode int
+}
+
+func newStatusCodeError(message string, minStatusCode int, maxStatusCode int) *statusCodeError {
+	return &statusCodeError{
+		message:       message,
+		minStatusCode: minStatusCode,
+		maxStatusCode: maxStatusCode,
+	}
+}
+
+func (e *statusCodeError) Error() string {
+	return e.message
+}
+
+func (e *statusCodeError) matches(statusCode int) bool {
+	return statusCode >= e.minStatusCode && statusCode <= e.maxStatusCode
+}
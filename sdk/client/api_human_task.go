@@ -380,7 +380,7 @@ func (a *HumanTaskApiService) ReassignTask(ctx context.Context, body []human.Hum
 	}
 
 	if !isSuccessfulStatus(httpResponse.StatusCode) {
-		return httpResponse, NewGenericSwaggerError(responseBody, httpResponse.Status, nil, httpResponse.StatusCode)
+		return httpResponse, newResponseError(httpResponse, responseBody, httpResponse.Status)
 	}
 
 	return httpResponse, nil
@@ -437,6 +437,23 @@ func (a *TaskResourceApiService) UpdateTask(ctx context.Context, taskResult *mod
 	return result, resp, nil
 }
 
+/*
+TaskResourceApiService Update several tasks at once, through POST /tasks/batch. The endpoint is not part of the Conductor
+OSS API, whose servers answer it with an error status. The batch is expected to be applied as a whole: on an error
+response, none of its results is taken as applied and each of them should be updated on its own.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param taskResults
+*/
+func (a *TaskResourceApiService) UpdateTasks(ctx context.Context, taskResults []*model.TaskResult) (*http.Response, error) {
+	path := "/tasks/batch"
+
+	resp, err := a.Post(ctx, path, taskResults, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 /*
 TaskResourceApiService Update a task By Ref Name synchronously. The output data is merged if data from a previous API call already exists.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
@@ -536,7 +553,7 @@ func (a *TaskResourceApiService) signalWorkflowTaskWithReturnStrategy(
 		err = a.decode(&signalResponse, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
 		localVarReturnValue = signalResponse
 	} else {
-		newErr := NewGenericSwaggerError(localVarBody, string(localVarBody), nil, localVarHttpResponse.StatusCode)
+		newErr := newResponseError(localVarHttpResponse, localVarBody, string(localVarBody))
 		return nil, localVarHttpResponse, newErr
 	}
 
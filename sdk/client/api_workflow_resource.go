@@ -729,7 +729,7 @@ func (a *WorkflowResourceApiService) executeWorkflowImpl(
 		err = a.decode(&signalResponse, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
 		localVarReturnValue = signalResponse
 	} else {
-		newErr := NewGenericSwaggerError(localVarBody, localVarHttpResponse.Status, nil, localVarHttpResponse.StatusCode)
+		newErr := newResponseError(localVarHttpResponse, localVarBody, localVarHttpResponse.Status)
 		return nil, localVarHttpResponse, newErr
 	}
 
@@ -984,11 +984,17 @@ WorkflowResourceApiService Start a new workflow with http_model.StartWorkflowReq
   - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @param body
 
+The request is sent with its idempotency key, if any, which allows retrying it.
+
 @return string
 */
 func (a *WorkflowResourceApiService) StartWorkflowWithRequest(ctx context.Context, body model.StartWorkflowRequest) (string, *http.Response, error) {
 	var result string
 
+	if body.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, body.IdempotencyKey)
+	}
+
 	path := "/workflow"
 
 	resp, err := a.Post(ctx, path, body, &result)
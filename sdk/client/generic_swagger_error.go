@@ -9,14 +9,27 @@
 
 package client
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
 
 // GenericSwaggerError Provides access to the body, error and model on returned errors.
+//
+// It is the error returned for every response of the server with an unsuccessful status code, and can be matched with
+// the sentinel errors of its status code, such as:
+//
+//	if errors.Is(err, client.ErrNotFound) { ... }
 type GenericSwaggerError struct {
 	body       []byte
 	error      string
 	model      interface{}
 	statusCode int
+	method     string
+	path       string
 }
 
 // Error returns non-empty string if there was an error.
@@ -29,7 +42,8 @@ func (e GenericSwaggerError) Body() []byte {
 	return e.body
 }
 
-// Model returns the unpacked model of the error
+// Model returns the unpacked model of the error, which is a *model.ErrorResponse when the body of the response could be
+// decoded as such.
 func (e GenericSwaggerError) Model() interface{} {
 	return e.model
 }
@@ -38,6 +52,54 @@ func (e GenericSwaggerError) StatusCode() int {
 	return e.statusCode
 }
 
+// Method returns the HTTP method of the failed request.
+func (e GenericSwaggerError) Method() string {
+	return e.method
+}
+
+// Path returns the path of the failed request.
+func (e GenericSwaggerError) Path() string {
+	return e.path
+}
+
+// ErrorResponse returns the error body sent by Conductor, or nil if the body of the response is not one.
+func (e GenericSwaggerError) ErrorResponse() *model.ErrorResponse {
+	errorResponse, _ := e.model.(*model.ErrorResponse)
+	return errorResponse
+}
+
+// Message returns the message of the error body sent by Conductor, or the raw body of the response if it is not one.
+func (e GenericSwaggerError) Message() string {
+	if errorResponse := e.ErrorResponse(); errorResponse != nil && errorResponse.Message != "" {
+		return errorResponse.Message
+	}
+	return string(e.body)
+}
+
+// ValidationErrors returns the validation errors of the error body sent by Conductor, if any.
+func (e GenericSwaggerError) ValidationErrors() []model.ValidationError {
+	if errorResponse := e.ErrorResponse(); errorResponse != nil {
+		return errorResponse.ValidationErrors
+	}
+	return nil
+}
+
+// IsRetryable tells whether the request may succeed if sent again, as told by the error body sent by Conductor, or
+// else by the status code: rate limited requests and server errors are retryable.
+func (e GenericSwaggerError) IsRetryable() bool {
+	if errorResponse := e.ErrorResponse(); errorResponse != nil && errorResponse.Retryable != nil {
+		return *errorResponse.Retryable
+	}
+	return e.statusCode == http.StatusTooManyRequests || e.statusCode >= http.StatusInternalServerError
+}
+
+// Is reports whether the status code of the error is the one of target, which is one of the sentinel errors such as
+// ErrNotFound.
+func (e GenericSwaggerError) Is(target error) bool {
+	statusErr, ok := target.(*statusCodeError)
+	return ok && statusErr.matches(e.statusCode)
+}
+
 func NewGenericSwaggerError(body []byte, errorMsg string, model interface{}, statusCode int) GenericSwaggerError {
 	return GenericSwaggerError{
 		body:       body,
@@ -47,11 +109,28 @@ func NewGenericSwaggerError(body []byte, errorMsg string, model interface{}, sta
 	}
 }
 
+// newResponseError returns the error of a response with an unsuccessful status code, decoding the error body sent by
+// Conductor.
+func newResponseError(response *http.Response, body []byte, errorMsg string) GenericSwaggerError {
+	err := NewGenericSwaggerError(body, errorMsg, nil, response.StatusCode)
+	if response.Request != nil {
+		err.method = response.Request.Method
+		err.path = response.Request.URL.Path
+	}
+	var errorResponse model.ErrorResponse
+	if json.Unmarshal(body, &errorResponse) == nil && (errorResponse.Message != "" || len(errorResponse.ValidationErrors) > 0) {
+		err.model = &errorResponse
+	}
+	return err
+}
+
 func WrapErrorMessage(err GenericSwaggerError, errorMsg string) GenericSwaggerError {
 	return GenericSwaggerError{
 		body:       err.body,
 		error:      errorMsg,
 		model:      err.model,
 		statusCode: err.statusCode,
+		method:     err.method,
+		path:       err.path,
 	}
 }
@@ -0,0 +1,128 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+const (
+	defaultHttpTimeout         = 30 * time.Second
+	defaultDialTimeout         = 30 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 100
+)
+
+// ErrInvalidHttpSettings is returned by the requests of an APIClient built from invalid HTTP settings.
+var ErrInvalidHttpSettings = errors.New("invalid HTTP settings")
+
+// newHttpClient returns the client sending the requests, as configured by the settings. When the settings are
+// invalid, the error is logged and returned by every request.
+func newHttpClient(httpSettings *settings.HttpSettings) *http.Client {
+	if httpSettings.HttpClient != nil {
+		return httpSettings.HttpClient
+	}
+	transport := httpSettings.Transport
+	if transport == nil {
+		var err error
+		transport, err = newTransport(httpSettings)
+		if err != nil {
+			log.Errorw("Invalid HTTP settings, requests will fail", log.ErrorKey, err)
+			transport = errorRoundTripper{err: fmt.Errorf("%w: %w", ErrInvalidHttpSettings, err)}
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   getHttpTimeout(httpSettings),
+	}
+}
+
+func newTransport(httpSettings *settings.HttpSettings) (*http.Transport, error) {
+	dialer := &net.Dialer{
+		Timeout:   durationOrDefault(httpSettings.DialTimeout, defaultDialTimeout),
+		KeepAlive: durationOrDefault(httpSettings.KeepAlive, defaultKeepAlive),
+	}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          intOrDefault(httpSettings.MaxIdleConns, defaultMaxIdleConns),
+		MaxIdleConnsPerHost:   intOrDefault(httpSettings.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost),
+		MaxConnsPerHost:       httpSettings.MaxConnsPerHost,
+		IdleConnTimeout:       httpSettings.IdleConnTimeout,
+		ResponseHeaderTimeout: httpSettings.ResponseHeaderTimeout,
+		DisableCompression:    false,
+	}
+	if httpSettings.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(httpSettings.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	if httpSettings.TLS != nil {
+		tlsConfig, err := httpSettings.TLS.NewTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
+
+// getHttpTimeout returns the timeout of the settings, falling back to the CONDUCTOR_CLIENT_HTTP_TIMEOUT environment
+// variable, in seconds.
+func getHttpTimeout(httpSettings *settings.HttpSettings) time.Duration {
+	if httpSettings.Timeout > 0 {
+		return httpSettings.Timeout
+	}
+	if timeoutStr := os.Getenv(CONDUCTOR_CLIENT_HTTP_TIMEOUT); timeoutStr != "" {
+		if timeoutInt, err := strconv.Atoi(timeoutStr); err == nil {
+			return time.Duration(timeoutInt) * time.Second
+		}
+	}
+	return defaultHttpTimeout
+}
+
+func durationOrDefault(value time.Duration, defaultValue time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func intOrDefault(value int, defaultValue int) int {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// errorRoundTripper fails every request with the error of the invalid HTTP settings.
+type errorRoundTripper struct {
+	err error
+}
+
+func (t errorRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		request.Body.Close()
+	}
+	return nil, t.err
+}
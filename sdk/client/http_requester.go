@@ -14,16 +14,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/conductor-sdk/conductor-go/sdk/authentication"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
 )
 
+const authorizationHeader = "X-Authorization"
+
+// tokenRejectedPattern matches the error codes in the body of the responses rejecting an expired or invalid token.
+var tokenRejectedPattern = regexp.MustCompile(`(?i)(EXPIRED|INVALID)_TOKEN`)
+
 type HttpRequester struct {
 	httpSettings *settings.HttpSettings
 	httpClient   *http.Client
@@ -162,12 +169,60 @@ func (h *HttpRequester) prepareRequest(
 		localVarRequest.Header.Add(header, value)
 	}
 
-	if h.tokenManager != nil {
-		token, err := h.tokenManager.RefreshToken(h.httpSettings, h.httpClient)
-		if err == nil {
-			localVarRequest.Header.Add("X-Authorization", token)
-		}
+	if err := h.authorize(localVarRequest); err != nil {
+		return nil, err
 	}
 
 	return localVarRequest, nil
 }
+
+// authorize sets the authentication token of the request.
+func (h *HttpRequester) authorize(request *http.Request) error {
+	if h.tokenManager == nil {
+		return nil
+	}
+	token, err := h.tokenManager.RefreshToken(h.httpSettings, h.httpClient)
+	if err != nil {
+		return fmt.Errorf("failed to get authentication token: %w", err)
+	}
+	request.Header.Set(authorizationHeader, token)
+	return nil
+}
+
+// isTokenRejected returns whether the server rejected the token of the request, because it is invalid or expired. The
+// body of the response is left unread.
+func (h *HttpRequester) isTokenRejected(request *http.Request, response *http.Response) bool {
+	if h.tokenManager == nil || request.Header.Get(authorizationHeader) == "" {
+		return false
+	}
+	switch response.StatusCode {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		body, err := io.ReadAll(io.LimitReader(response.Body, 4096))
+		response.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), response.Body), response.Body}
+		if err != nil {
+			return false
+		}
+		return tokenRejectedPattern.Match(body)
+	}
+	return false
+}
+
+// reauthorize invalidates the token rejected by the server, and returns a copy of the request with a new token.
+func (h *HttpRequester) reauthorize(request *http.Request) (*http.Request, error) {
+	if invalidator, ok := h.tokenManager.(authentication.TokenInvalidator); ok {
+		invalidator.InvalidateToken(request.Header.Get(authorizationHeader))
+	}
+	retry, err := rewindRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(retry); err != nil {
+		return nil, err
+	}
+	return retry, nil
+}
@@ -0,0 +1,163 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a request. Requests with a non-idempotent method
+// are only retried when it is set.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy decides which failed requests the APIClient retries, and how long it waits before doing so. The
+// APIClient does not retry requests unless a policy is set with SetRetryPolicy or WithRetryPolicy.
+//
+// Only requests with an idempotent method (GET, HEAD, OPTIONS, PUT, DELETE), or carrying an idempotency key, are
+// retried. They are retried when the connection fails, or when the server responds with one of RetryableStatusCodes.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// InitialInterval is the backoff before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff between two attempts. A Retry-After header asking for a longer wait is not
+	// retried.
+	MaxInterval time.Duration
+	// Multiplier is the factor by which the backoff grows after each attempt.
+	Multiplier float64
+	// RandomizationFactor spreads each backoff randomly over [backoff*(1-factor), backoff*(1+factor)].
+	RandomizationFactor float64
+	// RetryableStatusCodes are the HTTP status codes for which requests are retried.
+	RetryableStatusCodes []int
+}
+
+// NewRetryPolicy returns a RetryPolicy retrying up to 3 times, starting at 500ms and doubling up to 10s with a
+// randomization factor of 0.5. Requests are retried on 429, 502, 503 and 504. It is not used unless set on the
+// APIClient.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:          3,
+		InitialInterval:     500 * time.Millisecond,
+		MaxInterval:         10 * time.Second,
+		Multiplier:          2,
+		RandomizationFactor: 0.5,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+type retryPolicyContextKey struct{}
+
+type idempotencyKeyContextKey struct{}
+
+// WithRetryPolicy returns a context overriding the retry policy of the APIClient for the calls made with it. A nil
+// policy disables retries.
+func WithRetryPolicy(ctx context.Context, policy *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, policy)
+}
+
+// WithIdempotencyKey returns a context adding the idempotency key to the requests made with it, which allows retrying
+// them whatever their method.
+func WithIdempotencyKey(ctx context.Context, idempotencyKey string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, idempotencyKey)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	idempotencyKey, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return idempotencyKey
+}
+
+// isRetryable returns whether the request can be retried after failing with the response or err.
+func (p *RetryPolicy) isRetryable(request *http.Request, response *http.Response, err error) bool {
+	if p == nil || p.MaxRetries <= 0 {
+		return false
+	}
+	if !isIdempotent(request.Method) && request.Header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrInvalidHttpSettings)
+	}
+	for _, statusCode := range p.RetryableStatusCodes {
+		if response.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the time to wait before the given retry attempt, starting at 1. No more retries are made once it
+// returns false.
+func (p *RetryPolicy) backoff(attempt int, response *http.Response) (time.Duration, bool) {
+	if attempt > p.MaxRetries {
+		return 0, false
+	}
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if p.MaxInterval > 0 && retryAfter > p.MaxInterval {
+				return 0, false
+			}
+			return retryAfter, true
+		}
+	}
+	backoff := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxInterval > 0 && backoff > float64(p.MaxInterval) {
+		backoff = float64(p.MaxInterval)
+	}
+	if p.RandomizationFactor > 0 {
+		delta := p.RandomizationFactor * backoff
+		backoff = backoff - delta + rand.Float64()*2*delta
+	}
+	return time.Duration(backoff), true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header holding either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	retryAfter := time.Until(date)
+	if retryAfter < 0 {
+		retryAfter = 0
+	}
+	return retryAfter, true
+}
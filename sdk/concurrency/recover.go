@@ -10,11 +10,13 @@
 package concurrency
 
 import (
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/metrics"
-	log "github.com/sirupsen/logrus"
 	"runtime/debug"
 )
 
+// HandlePanicError recovers from a panic, counting it as an uncaught exception. The message identifies where the
+// panic was recovered in the logs.
 func HandlePanicError(message string) {
 	err := recover()
 	if err == nil {
@@ -22,10 +24,23 @@ func HandlePanicError(message string) {
 	}
 	metrics.IncrementUncaughtException(message)
 
-	log.Error(
+	log.Errorw("Uncaught panic", "message", message, log.ErrorKey, err, "stack", string(debug.Stack()))
+}
+
+// HandleTaskPanicError recovers from a panic raised while working on tasks of the given type, counting it as an
+// uncaught exception of that task type.
+func HandleTaskPanicError(taskType string, message string) {
+	err := recover()
+	if err == nil {
+		return
+	}
+	metrics.IncrementTaskUncaughtException(taskType)
+
+	log.Errorw(
 		"Uncaught panic",
-		", message: ", message,
-		", error: ", err,
-		", stack: ", string(debug.Stack()),
+		log.TaskTypeKey, taskType,
+		"message", message,
+		log.ErrorKey, err,
+		"stack", string(debug.Stack()),
 	)
 }
@@ -1,35 +1,223 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+// Package log holds the logger used across the SDK. Applications inject their own logger with SetLogger, either a
+// printf-style Logger, such as a *logrus.Logger, or a StructuredLogger built with one of the adapters for slog, zap
+// and logrus or their own implementation. By default, the SDK logs with the production logger of zap.
+//
+// Info, Debug, Trace, Warning and Error log their args concatenated, as with fmt.Sprint. The SDK logs with Infow,
+// Debugw, Tracew, Warnw and Errorw, which take a message followed by alternating keys and values.
 package log
 
-import "go.uber.org/zap"
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Keys of the structured fields of SDK log lines.
+const (
+	TaskTypeKey   = "taskType"
+	TaskIdKey     = "taskId"
+	WorkflowIdKey = "workflowId"
+	DomainKey     = "domain"
+	ErrorKey      = "error"
+)
+
+// Logger is a printf-style logger, implemented by *logrus.Logger among others. The fields of the messages logged by
+// the SDK are appended to them as key=value pairs.
+type Logger interface {
+	Tracef(format string, args ...interface{})
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// StructuredLogger is a Logger which also logs messages with alternating keys and values, such as:
+//
+//	logger.Info("Polled tasks", log.TaskTypeKey, taskName, "count", len(tasks))
+type StructuredLogger interface {
+	Logger
+	Trace(msg string, keysAndValues ...interface{})
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+	// With returns a StructuredLogger adding the provided keys and values to every message.
+	With(keysAndValues ...interface{}) StructuredLogger
+}
 
 var (
-	sugar *zap.SugaredLogger
+	loggerMutex sync.RWMutex
+	logger      = newDefaultLogger()
 )
 
-func init() {
-	logger, err := zap.NewProduction()
+// newDefaultLogger returns the production logger of zap, which the SDK logs with unless SetLogger is called.
+func newDefaultLogger() StructuredLogger {
+	zapLogger, err := zap.NewProduction()
 	if err != nil {
-		panic("failed to initialize zap logger: " + err.Error()) // Handle error appropriately
+		panic("failed to initialize zap logger: " + err.Error())
 	}
-	defer logger.Sync() // flushes buffer, if any
-	sugar = logger.Sugar()
+	return NewZapLogger(zapLogger)
 }
 
-func Info(args ...interface{}) {
-	sugar.Info(args)
+// SetLogger sets the logger used across the SDK. A Logger which is not a StructuredLogger gets the fields of the
+// messages appended to them.
+func SetLogger(l Logger) {
+	structuredLogger, ok := l.(StructuredLogger)
+	if !ok {
+		structuredLogger = &printfLogger{Logger: l}
+	}
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+	logger = structuredLogger
 }
-func Debug(args ...interface{}) {
-	sugar.Debug(args)
+
+// GetLogger returns the logger used across the SDK.
+func GetLogger() StructuredLogger {
+	loggerMutex.RLock()
+	defer loggerMutex.RUnlock()
+	return logger
+}
+
+// With returns a StructuredLogger adding the provided keys and values to every message of the logger used across the
+// SDK.
+func With(keysAndValues ...interface{}) StructuredLogger {
+	return GetLogger().With(keysAndValues...)
 }
+
+// Tracew logs a message with alternating keys and values at trace level.
+func Tracew(msg string, keysAndValues ...interface{}) {
+	GetLogger().Trace(msg, keysAndValues...)
+}
+
+// Debugw logs a message with alternating keys and values at debug level.
+func Debugw(msg string, keysAndValues ...interface{}) {
+	GetLogger().Debug(msg, keysAndValues...)
+}
+
+// Infow logs a message with alternating keys and values at info level.
+func Infow(msg string, keysAndValues ...interface{}) {
+	GetLogger().Info(msg, keysAndValues...)
+}
+
+// Warnw logs a message with alternating keys and values at warn level.
+func Warnw(msg string, keysAndValues ...interface{}) {
+	GetLogger().Warn(msg, keysAndValues...)
+}
+
+// Errorw logs a message with alternating keys and values at error level.
+func Errorw(msg string, keysAndValues ...interface{}) {
+	GetLogger().Error(msg, keysAndValues...)
+}
+
+// Trace logs the args, concatenated as with fmt.Sprint, at trace level.
 func Trace(args ...interface{}) {
-	sugar.Debug(args)
+	GetLogger().Tracef("%s", fmt.Sprint(args...))
 }
-func Error(args ...interface{}) {
-	sugar.Error(args)
+
+// Debug logs the args, concatenated as with fmt.Sprint, at debug level.
+func Debug(args ...interface{}) {
+	GetLogger().Debugf("%s", fmt.Sprint(args...))
+}
+
+// Info logs the args, concatenated as with fmt.Sprint, at info level.
+func Info(args ...interface{}) {
+	GetLogger().Infof("%s", fmt.Sprint(args...))
 }
+
+// Warning logs the args, concatenated as with fmt.Sprint, at warn level.
 func Warning(args ...interface{}) {
-	sugar.Warn(args)
+	GetLogger().Warnf("%s", fmt.Sprint(args...))
+}
+
+// Error logs the args, concatenated as with fmt.Sprint, at error level.
+func Error(args ...interface{}) {
+	GetLogger().Errorf("%s", fmt.Sprint(args...))
 }
+
+// Fatalf logs a message formatted according to a format specifier as an error, and exits with status 1.
 func Fatalf(format string, args ...interface{}) {
-	sugar.Fatalf(format, args...)
+	GetLogger().Errorf(format, args...)
+	os.Exit(1)
+}
+
+// badKey is the key of values without key, as slog names them. It is suffixed with the index of the value, so that
+// several values without key are all kept.
+const badKey = "!BADKEY"
+
+// toFields pairs alternating keys and values.
+func toFields(keysAndValues []interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(keysAndValues)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok || i+1 == len(keysAndValues) {
+			fields[badKey+strconv.Itoa(i)] = keysAndValues[i]
+			i -= 1
+			continue
+		}
+		fields[key] = keysAndValues[i+1]
+	}
+	return fields
+}
+
+// printfLogger is the StructuredLogger of a printf-style Logger, which appends the fields to the messages.
+type printfLogger struct {
+	Logger
+	keysAndValues []interface{}
+}
+
+func (l *printfLogger) Trace(msg string, keysAndValues ...interface{}) {
+	l.Tracef("%s", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.Debugf("%s", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.Infof("%s", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.Warnf("%s", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.Errorf("%s", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) With(keysAndValues ...interface{}) StructuredLogger {
+	return &printfLogger{
+		Logger:        l.Logger,
+		keysAndValues: append(append([]interface{}{}, l.keysAndValues...), keysAndValues...),
+	}
+}
+
+// format appends the fields of the logger and the provided ones to msg, sorted by key.
+func (l *printfLogger) format(msg string, keysAndValues []interface{}) string {
+	fields := toFields(append(append([]interface{}{}, l.keysAndValues...), keysAndValues...))
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var builder strings.Builder
+	builder.WriteString(msg)
+	for _, key := range keys {
+		fmt.Fprintf(&builder, " %s=%v", key, fields[key])
+	}
+	return builder.String()
 }
@@ -0,0 +1,65 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package log
+
+import "github.com/sirupsen/logrus"
+
+type logrusLogger struct {
+	entry *logrus.Entry
+}
+
+// NewLogrusLogger returns a StructuredLogger writing to the provided logrus logger, with keys and values as fields.
+func NewLogrusLogger(logger *logrus.Logger) StructuredLogger {
+	return &logrusLogger{entry: logrus.NewEntry(logger)}
+}
+
+func (l *logrusLogger) Trace(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(toFields(keysAndValues)).Trace(msg)
+}
+
+func (l *logrusLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(toFields(keysAndValues)).Debug(msg)
+}
+
+func (l *logrusLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(toFields(keysAndValues)).Info(msg)
+}
+
+func (l *logrusLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(toFields(keysAndValues)).Warn(msg)
+}
+
+func (l *logrusLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(toFields(keysAndValues)).Error(msg)
+}
+
+func (l *logrusLogger) With(keysAndValues ...interface{}) StructuredLogger {
+	return &logrusLogger{entry: l.entry.WithFields(toFields(keysAndValues))}
+}
+
+func (l *logrusLogger) Tracef(format string, args ...interface{}) {
+	l.entry.Tracef(format, args...)
+}
+
+func (l *logrusLogger) Debugf(format string, args ...interface{}) {
+	l.entry.Debugf(format, args...)
+}
+
+func (l *logrusLogger) Infof(format string, args ...interface{}) {
+	l.entry.Infof(format, args...)
+}
+
+func (l *logrusLogger) Warnf(format string, args ...interface{}) {
+	l.entry.Warnf(format, args...)
+}
+
+func (l *logrusLogger) Errorf(format string, args ...interface{}) {
+	l.entry.Errorf(format, args...)
+}
@@ -0,0 +1,66 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package log
+
+import "go.uber.org/zap"
+
+type zapLogger struct {
+	sugar *zap.SugaredLogger
+}
+
+// NewZapLogger returns a StructuredLogger writing to the provided zap logger. Zap has no trace level, so trace
+// messages are logged at debug level.
+func NewZapLogger(logger *zap.Logger) StructuredLogger {
+	return &zapLogger{sugar: logger.Sugar()}
+}
+
+func (l *zapLogger) Trace(msg string, keysAndValues ...interface{}) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}
+
+func (l *zapLogger) With(keysAndValues ...interface{}) StructuredLogger {
+	return &zapLogger{sugar: l.sugar.With(keysAndValues...)}
+}
+
+func (l *zapLogger) Tracef(format string, args ...interface{}) {
+	l.sugar.Debugf(format, args...)
+}
+
+func (l *zapLogger) Debugf(format string, args ...interface{}) {
+	l.sugar.Debugf(format, args...)
+}
+
+func (l *zapLogger) Infof(format string, args ...interface{}) {
+	l.sugar.Infof(format, args...)
+}
+
+func (l *zapLogger) Warnf(format string, args ...interface{}) {
+	l.sugar.Warnf(format, args...)
+}
+
+func (l *zapLogger) Errorf(format string, args ...interface{}) {
+	l.sugar.Errorf(format, args...)
+}
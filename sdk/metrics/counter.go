@@ -9,12 +9,6 @@
 
 package metrics
 
-import (
-	"github.com/prometheus/client_golang/prometheus"
-)
-
-var counterByName = map[MetricName]*prometheus.CounterVec{}
-
 var counterTemplates = map[MetricName]*MetricDetails{
 	TASK_POLL: NewMetricDetails(
 		TASK_POLL,
@@ -33,7 +27,9 @@ var counterTemplates = map[MetricName]*MetricDetails{
 	THREAD_UNCAUGHT_EXCEPTION: NewMetricDetails(
 		THREAD_UNCAUGHT_EXCEPTION,
 		THREAD_UNCAUGHT_EXCEPTION_DOC,
-		[]MetricLabel{},
+		[]MetricLabel{
+			TASK_TYPE,
+		},
 	),
 	TASK_POLL_ERROR: NewMetricDetails(
 		TASK_POLL_ERROR,
@@ -82,8 +78,8 @@ var counterTemplates = map[MetricName]*MetricDetails{
 	),
 }
 
-func IncrementTaskPoll(taskType string) {
-	incrementCounter(
+func (m *Metrics) IncrementTaskPoll(taskType string) {
+	m.incrementCounter(
 		TASK_POLL,
 		[]string{
 			taskType,
@@ -91,8 +87,12 @@ func IncrementTaskPoll(taskType string) {
 	)
 }
 
-func IncrementTaskExecutionQueueFull(taskType string) {
-	incrementCounter(
+func IncrementTaskPoll(taskType string) {
+	Default().IncrementTaskPoll(taskType)
+}
+
+func (m *Metrics) IncrementTaskExecutionQueueFull(taskType string) {
+	m.incrementCounter(
 		TASK_EXECUTION_QUEUE_FULL,
 		[]string{
 			taskType,
@@ -100,17 +100,40 @@ func IncrementTaskExecutionQueueFull(taskType string) {
 	)
 }
 
+func IncrementTaskExecutionQueueFull(taskType string) {
+	Default().IncrementTaskExecutionQueueFull(taskType)
+}
+
+// IncrementUncaughtException counts a panic raised out of any task, which is recorded with an empty task type. The
+// message is not recorded.
+func (m *Metrics) IncrementUncaughtException(message string) {
+	m.incrementCounter(
+		THREAD_UNCAUGHT_EXCEPTION,
+		[]string{
+			"",
+		},
+	)
+}
+
 func IncrementUncaughtException(message string) {
-	incrementCounter(
+	Default().IncrementUncaughtException(message)
+}
+
+func (m *Metrics) IncrementTaskUncaughtException(taskType string) {
+	m.incrementCounter(
 		THREAD_UNCAUGHT_EXCEPTION,
 		[]string{
-			message,
+			taskType,
 		},
 	)
 }
 
-func IncrementTaskPollError(taskType string, err error) {
-	incrementCounter(
+func IncrementTaskUncaughtException(taskType string) {
+	Default().IncrementTaskUncaughtException(taskType)
+}
+
+func (m *Metrics) IncrementTaskPollError(taskType string, err error) {
+	m.incrementCounter(
 		TASK_POLL_ERROR,
 		[]string{
 			taskType,
@@ -118,8 +141,12 @@ func IncrementTaskPollError(taskType string, err error) {
 	)
 }
 
-func IncrementTaskPaused(taskType string) {
-	incrementCounter(
+func IncrementTaskPollError(taskType string, err error) {
+	Default().IncrementTaskPollError(taskType, err)
+}
+
+func (m *Metrics) IncrementTaskPaused(taskType string) {
+	m.incrementCounter(
 		TASK_PAUSED,
 		[]string{
 			taskType,
@@ -127,8 +154,12 @@ func IncrementTaskPaused(taskType string) {
 	)
 }
 
-func IncrementTaskExecuteError(taskType string, err error) {
-	incrementCounter(
+func IncrementTaskPaused(taskType string) {
+	Default().IncrementTaskPaused(taskType)
+}
+
+func (m *Metrics) IncrementTaskExecuteError(taskType string, err error) {
+	m.incrementCounter(
 		TASK_EXECUTE_ERROR,
 		[]string{
 			taskType,
@@ -136,8 +167,12 @@ func IncrementTaskExecuteError(taskType string, err error) {
 	)
 }
 
-func IncrementTaskUpdateError(taskType string, err error) {
-	incrementCounter(
+func IncrementTaskExecuteError(taskType string, err error) {
+	Default().IncrementTaskExecuteError(taskType, err)
+}
+
+func (m *Metrics) IncrementTaskUpdateError(taskType string, err error) {
+	m.incrementCounter(
 		TASK_UPDATE_ERROR,
 		[]string{
 			taskType,
@@ -145,8 +180,12 @@ func IncrementTaskUpdateError(taskType string, err error) {
 	)
 }
 
-func IncrementExternalPayloadUsed(entityName string, operation string, payloadType string) {
-	incrementCounter(
+func IncrementTaskUpdateError(taskType string, err error) {
+	Default().IncrementTaskUpdateError(taskType, err)
+}
+
+func (m *Metrics) IncrementExternalPayloadUsed(entityName string, operation string, payloadType string) {
+	m.incrementCounter(
 		EXTERNAL_PAYLOAD_USED,
 		[]string{
 			entityName,
@@ -156,8 +195,12 @@ func IncrementExternalPayloadUsed(entityName string, operation string, payloadTy
 	)
 }
 
-func IncrementWorkflowStartError(workflowType string, err error) {
-	incrementCounter(
+func IncrementExternalPayloadUsed(entityName string, operation string, payloadType string) {
+	Default().IncrementExternalPayloadUsed(entityName, operation, payloadType)
+}
+
+func (m *Metrics) IncrementWorkflowStartError(workflowType string, err error) {
+	m.incrementCounter(
 		WORKFLOW_START_ERROR,
 		[]string{
 			workflowType,
@@ -165,35 +208,13 @@ func IncrementWorkflowStartError(workflowType string, err error) {
 	)
 }
 
-func incrementCounter(metricName MetricName, labelValues []string) {
-	// We skip incrementing if metrics collection is not yet enabled
-	if !collectionEnabled {
-		return
-	}
-
-	counter := getCounter(metricName, labelValues)
-	if *counter != nil {
-		(*counter).Inc()
-	}
-}
-
-func newCounter(metricDetails *MetricDetails) *prometheus.CounterVec {
-	return prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: metricDetails.Name,
-			Help: metricDetails.Description,
-		},
-		metricDetails.Labels,
-	)
+func IncrementWorkflowStartError(workflowType string, err error) {
+	Default().IncrementWorkflowStartError(workflowType, err)
 }
 
-func getCounter(metricName MetricName, labelValues []string) *prometheus.Counter {
-	counterVec, ok := counterByName[metricName]
-	if !ok {
-		return nil
+func (m *Metrics) incrementCounter(metricName MetricName, labelValues []string) {
+	if m == nil {
+		return
 	}
-	counter, _ := counterVec.GetMetricWithLabelValues(
-		labelValues...,
-	)
-	return &counter
+	m.recorder.IncrementCounter(metricName, labelValues)
 }
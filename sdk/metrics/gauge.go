@@ -9,12 +9,6 @@
 
 package metrics
 
-import (
-	"github.com/prometheus/client_golang/prometheus"
-)
-
-var gaugeByName = map[MetricName]*prometheus.GaugeVec{}
-
 var gaugeTemplates = map[MetricName]*MetricDetails{
 	WORKFLOW_INPUT_SIZE: NewMetricDetails(
 		WORKFLOW_INPUT_SIZE,
@@ -54,8 +48,8 @@ var gaugeTemplates = map[MetricName]*MetricDetails{
 	),
 }
 
-func RecordWorkflowInputPayloadSize(workflowType string, version string, payloadSize float64) {
-	setGauge(
+func (m *Metrics) RecordWorkflowInputPayloadSize(workflowType string, version string, payloadSize float64) {
+	m.setGauge(
 		WORKFLOW_INPUT_SIZE,
 		[]string{
 			workflowType,
@@ -65,8 +59,12 @@ func RecordWorkflowInputPayloadSize(workflowType string, version string, payload
 	)
 }
 
-func RecordTaskResultPayloadSize(taskType string, payloadSize float64) {
-	setGauge(
+func RecordWorkflowInputPayloadSize(workflowType string, version string, payloadSize float64) {
+	Default().RecordWorkflowInputPayloadSize(workflowType, version, payloadSize)
+}
+
+func (m *Metrics) RecordTaskResultPayloadSize(taskType string, payloadSize float64) {
+	m.setGauge(
 		TASK_RESULT_SIZE,
 		[]string{
 			taskType,
@@ -75,8 +73,12 @@ func RecordTaskResultPayloadSize(taskType string, payloadSize float64) {
 	)
 }
 
-func RecordTaskPollTime(taskType string, timeSpent float64) {
-	setGauge(
+func RecordTaskResultPayloadSize(taskType string, payloadSize float64) {
+	Default().RecordTaskResultPayloadSize(taskType, payloadSize)
+}
+
+func (m *Metrics) RecordTaskPollTime(taskType string, timeSpent float64) {
+	m.setGauge(
 		TASK_POLL_TIME,
 		[]string{
 			taskType,
@@ -85,8 +87,12 @@ func RecordTaskPollTime(taskType string, timeSpent float64) {
 	)
 }
 
-func RecordTaskUpdateTime(taskType string, timeSpent float64) {
-	setGauge(
+func RecordTaskPollTime(taskType string, timeSpent float64) {
+	Default().RecordTaskPollTime(taskType, timeSpent)
+}
+
+func (m *Metrics) RecordTaskUpdateTime(taskType string, timeSpent float64) {
+	m.setGauge(
 		TASK_UPDATE_TIME,
 		[]string{
 			taskType,
@@ -95,8 +101,12 @@ func RecordTaskUpdateTime(taskType string, timeSpent float64) {
 	)
 }
 
-func RecordTaskExecuteTime(taskType string, timeSpent float64) {
-	setGauge(
+func RecordTaskUpdateTime(taskType string, timeSpent float64) {
+	Default().RecordTaskUpdateTime(taskType, timeSpent)
+}
+
+func (m *Metrics) RecordTaskExecuteTime(taskType string, timeSpent float64) {
+	m.setGauge(
 		TASK_EXECUTE_TIME,
 		[]string{
 			taskType,
@@ -105,35 +115,13 @@ func RecordTaskExecuteTime(taskType string, timeSpent float64) {
 	)
 }
 
-func newGauge(metricDetails *MetricDetails) *prometheus.GaugeVec {
-	return prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: metricDetails.Name,
-			Help: metricDetails.Description,
-		},
-		metricDetails.Labels,
-	)
+func RecordTaskExecuteTime(taskType string, timeSpent float64) {
+	Default().RecordTaskExecuteTime(taskType, timeSpent)
 }
 
-func setGauge(metricName MetricName, labelValues []string, value float64) {
-	// We skip setting gauge if Metrics collection is not enabled
-	if !collectionEnabled {
+func (m *Metrics) setGauge(metricName MetricName, labelValues []string, value float64) {
+	if m == nil {
 		return
 	}
-
-	gauge := getGauge(metricName, labelValues)
-	if *gauge != nil {
-		(*gauge).Set(value)
-	}
-}
-
-func getGauge(metricName MetricName, labelValues []string) *prometheus.Gauge {
-	gaugeVec, ok := gaugeByName[metricName]
-	if !ok {
-		return nil
-	}
-	gauge, _ := gaugeVec.GetMetricWithLabelValues(
-		labelValues...,
-	)
-	return &gauge
+	m.recorder.SetGauge(metricName, labelValues, value)
 }
@@ -0,0 +1,114 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const instrumentationName = "github.com/conductor-sdk/conductor-go"
+
+// OtelRecorder records metrics as OpenTelemetry counters and gauges. Gauges are observed with the last value set for
+// each combination of labels.
+type OtelRecorder struct {
+	counterByName map[MetricName]metric.Int64Counter
+	gaugeByName   map[MetricName]metric.Float64ObservableGauge
+
+	gaugeValuesMutex sync.Mutex
+	gaugeValues      map[MetricName]map[string]gaugeValue
+}
+
+type gaugeValue struct {
+	attributes attribute.Set
+	value      float64
+}
+
+// NewOtelRecorder returns an OtelRecorder with its instruments created by a meter of the provided meter provider.
+func NewOtelRecorder(meterProvider metric.MeterProvider) (*OtelRecorder, error) {
+	meter := meterProvider.Meter(instrumentationName)
+	recorder := &OtelRecorder{
+		counterByName: map[MetricName]metric.Int64Counter{},
+		gaugeByName:   map[MetricName]metric.Float64ObservableGauge{},
+		gaugeValues:   map[MetricName]map[string]gaugeValue{},
+	}
+	for metricName, metricDetails := range counterTemplates {
+		counter, err := meter.Int64Counter(metricDetails.Name, metric.WithDescription(metricDetails.Description))
+		if err != nil {
+			return nil, err
+		}
+		recorder.counterByName[metricName] = counter
+	}
+	observables := make([]metric.Observable, 0, len(gaugeTemplates))
+	for metricName, metricDetails := range gaugeTemplates {
+		gauge, err := meter.Float64ObservableGauge(metricDetails.Name, metric.WithDescription(metricDetails.Description))
+		if err != nil {
+			return nil, err
+		}
+		recorder.gaugeByName[metricName] = gauge
+		observables = append(observables, gauge)
+	}
+	_, err := meter.RegisterCallback(recorder.observeGauges, observables...)
+	if err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func (r *OtelRecorder) IncrementCounter(metricName MetricName, labelValues []string) {
+	counter, ok := r.counterByName[metricName]
+	if !ok {
+		return
+	}
+	counter.Add(context.Background(), 1, metric.WithAttributeSet(getAttributes(metricName, labelValues)))
+}
+
+func (r *OtelRecorder) SetGauge(metricName MetricName, labelValues []string, value float64) {
+	if _, ok := r.gaugeByName[metricName]; !ok {
+		return
+	}
+	r.gaugeValuesMutex.Lock()
+	defer r.gaugeValuesMutex.Unlock()
+	values, ok := r.gaugeValues[metricName]
+	if !ok {
+		values = map[string]gaugeValue{}
+		r.gaugeValues[metricName] = values
+	}
+	values[strings.Join(labelValues, "\x00")] = gaugeValue{
+		attributes: getAttributes(metricName, labelValues),
+		value:      value,
+	}
+}
+
+func (r *OtelRecorder) observeGauges(ctx context.Context, observer metric.Observer) error {
+	r.gaugeValuesMutex.Lock()
+	defer r.gaugeValuesMutex.Unlock()
+	for metricName, values := range r.gaugeValues {
+		for _, value := range values {
+			observer.ObserveFloat64(r.gaugeByName[metricName], value.value, metric.WithAttributeSet(value.attributes))
+		}
+	}
+	return nil
+}
+
+func getAttributes(metricName MetricName, labelValues []string) attribute.Set {
+	labels := GetMetricDetails(metricName).Labels
+	attributes := make([]attribute.KeyValue, 0, len(labels))
+	for i, label := range labels {
+		if i < len(labelValues) {
+			attributes = append(attributes, attribute.String(label, labelValues[i]))
+		}
+	}
+	return attribute.NewSet(attributes...)
+}
@@ -0,0 +1,93 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusRecorder records metrics as Prometheus counters and gauges.
+type PrometheusRecorder struct {
+	counterByName map[MetricName]*prometheus.CounterVec
+	gaugeByName   map[MetricName]*prometheus.GaugeVec
+}
+
+// NewPrometheusRecorder returns a PrometheusRecorder with its metrics registered in the provided registerer, such as
+// a prometheus.Registry of the application.
+func NewPrometheusRecorder(registerer prometheus.Registerer) (*PrometheusRecorder, error) {
+	recorder := &PrometheusRecorder{
+		counterByName: map[MetricName]*prometheus.CounterVec{},
+		gaugeByName:   map[MetricName]*prometheus.GaugeVec{},
+	}
+	for metricName, metricDetails := range counterTemplates {
+		counter := prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricDetails.Name,
+				Help: metricDetails.Description,
+			},
+			metricDetails.Labels,
+		)
+		if err := registerer.Register(counter); err != nil {
+			return nil, err
+		}
+		recorder.counterByName[metricName] = counter
+	}
+	for metricName, metricDetails := range gaugeTemplates {
+		gauge := prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricDetails.Name,
+				Help: metricDetails.Description,
+			},
+			metricDetails.Labels,
+		)
+		if err := registerer.Register(gauge); err != nil {
+			return nil, err
+		}
+		recorder.gaugeByName[metricName] = gauge
+	}
+	return recorder, nil
+}
+
+func (r *PrometheusRecorder) IncrementCounter(metricName MetricName, labelValues []string) {
+	counterVec, ok := r.counterByName[metricName]
+	if !ok {
+		return
+	}
+	counter, err := counterVec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return
+	}
+	counter.Inc()
+}
+
+func (r *PrometheusRecorder) SetGauge(metricName MetricName, labelValues []string, value float64) {
+	gaugeVec, ok := r.gaugeByName[metricName]
+	if !ok {
+		return
+	}
+	gauge, err := gaugeVec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return
+	}
+	gauge.Set(value)
+}
+
+// NewPrometheusHandler returns an http.Handler publishing the metrics of the provided gatherer.
+func NewPrometheusHandler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(
+		gatherer,
+		promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		},
+	)
+}
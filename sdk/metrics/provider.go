@@ -10,48 +10,29 @@
 package metrics
 
 import (
-	"net/http"
-	"strconv"
-
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-var collectionEnabled bool = false
-
 // ProvideMetrics start collecting metrics for the workers
 // We use prometheus to collect metrics from the workers.  When called this function starts the metrics server and publishes the worker metrics
+//
+// Metrics are registered in the global Prometheus registry. To use a registry of your own, or a server which can be
+// shut down, see NewPrometheusRecorder, SetRecorder and NewMetricsServer.
 func ProvideMetrics(metricsSettings *settings.MetricsSettings) {
 	defer handlePanicError("provide_metrics")
-	if metricsSettings == nil {
-		metricsSettings = settings.NewDefaultMetricsSettings()
-	}
-
-	for metricName, metricDetails := range counterTemplates {
-		counterByName[metricName] = newCounter(metricDetails)
-		prometheus.MustRegister(counterByName[metricName])
+	recorder, err := NewPrometheusRecorder(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Errorw("Failed to register metrics", log.ErrorKey, err)
+		return
 	}
-
-	for metricName, metricDetails := range gaugeTemplates {
-		gaugeByName[metricName] = newGauge(metricDetails)
-		prometheus.MustRegister(gaugeByName[metricName])
+	SetRecorder(recorder)
+	err = NewMetricsServer(metricsSettings, prometheus.DefaultGatherer).ListenAndServe()
+	if err != nil {
+		log.Errorw("Failed to serve metrics", log.ErrorKey, err)
 	}
-	collectionEnabled = true
-	
-	http.Handle(
-		metricsSettings.ApiEndpoint,
-		promhttp.HandlerFor(
-			prometheus.DefaultGatherer,
-			promhttp.HandlerOpts{
-				EnableOpenMetrics: true,
-			},
-		),
-	)
-	portString := strconv.Itoa(metricsSettings.Port)
-	http.ListenAndServe(":"+portString, nil)
 }
 
 func handlePanicError(message string) {
@@ -60,9 +41,5 @@ func handlePanicError(message string) {
 		return
 	}
 	IncrementUncaughtException(message)
-	log.Warning(
-		"Uncaught panic",
-		", message: ", message,
-		", error: ", err,
-	)
+	log.Warnw("Uncaught panic", "message", message, log.ErrorKey, err)
 }
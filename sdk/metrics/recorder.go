@@ -0,0 +1,53 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package metrics
+
+import "sync/atomic"
+
+// Recorder records the metrics of the SDK in a metrics backend, such as the ones returned by NewPrometheusRecorder and
+// NewOtelRecorder. Label values are in the order of the labels of the metric, as returned by GetMetricDetails.
+type Recorder interface {
+	IncrementCounter(metricName MetricName, labelValues []string)
+	SetGauge(metricName MetricName, labelValues []string, value float64)
+}
+
+// Metrics records the metrics of the SDK with a Recorder. A nil Metrics records nothing.
+type Metrics struct {
+	recorder Recorder
+}
+
+// NewMetrics returns Metrics recorded with the provided recorder, or nil if recorder is nil.
+func NewMetrics(recorder Recorder) *Metrics {
+	if recorder == nil {
+		return nil
+	}
+	return &Metrics{recorder: recorder}
+}
+
+var defaultMetrics atomic.Pointer[Metrics]
+
+// SetRecorder sets the recorder of the metrics which are not recorded with Metrics of their own, such as the ones of a
+// TaskRunner with a recorder set. Nil disables the collection of metrics, which is the default.
+func SetRecorder(recorder Recorder) {
+	defaultMetrics.Store(NewMetrics(recorder))
+}
+
+// Default returns the Metrics recorded with the recorder set with SetRecorder, or nil if there is none.
+func Default() *Metrics {
+	return defaultMetrics.Load()
+}
+
+// GetMetricDetails returns the name, description and labels of a metric, or nil if the metric is unknown.
+func GetMetricDetails(metricName MetricName) *MetricDetails {
+	if details, ok := counterTemplates[metricName]; ok {
+		return details
+	}
+	return gaugeTemplates[metricName]
+}
@@ -0,0 +1,55 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsServer publishes Prometheus metrics over HTTP, on a server of its own which can be shut down.
+type MetricsServer struct {
+	server *http.Server
+}
+
+// NewMetricsServer returns a MetricsServer publishing the metrics of the provided gatherer on the endpoint and port of
+// the settings.
+func NewMetricsServer(metricsSettings *settings.MetricsSettings, gatherer prometheus.Gatherer) *MetricsServer {
+	if metricsSettings == nil {
+		metricsSettings = settings.NewDefaultMetricsSettings()
+	}
+	mux := http.NewServeMux()
+	mux.Handle(metricsSettings.ApiEndpoint, NewPrometheusHandler(gatherer))
+	return &MetricsServer{
+		server: &http.Server{
+			Addr:    ":" + strconv.Itoa(metricsSettings.Port),
+			Handler: mux,
+		},
+	}
+}
+
+// ListenAndServe serves the metrics until the server is shut down, in which case it returns nil.
+func (s *MetricsServer) ListenAndServe() error {
+	err := s.server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the server, waiting for the requests being served until ctx is done.
+func (s *MetricsServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -10,11 +10,12 @@
 package model
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"sync"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 )
 
 var hostname string
@@ -22,6 +23,10 @@ var once sync.Once
 
 type ExecuteTaskFunction func(t *Task) (interface{}, error)
 
+// ExecuteTaskFunctionWithContext is an ExecuteTaskFunction which receives a context.Context. The context is cancelled
+// when the worker is shut down or when the task's response/execution timeout elapses.
+type ExecuteTaskFunctionWithContext func(ctx context.Context, t *Task) (interface{}, error)
+
 type ValidateWorkflowFunction func(w *Workflow) (bool, error)
 
 func NewTaskResultFromTask(task *Task) *TaskResult {
@@ -73,10 +78,7 @@ func ConvertToMap(input interface{}) (map[string]interface{}, error) {
 	}
 	data, err := json.Marshal(input)
 	if err != nil {
-		log.Debug(
-			"Failed to parse input",
-			", reason: ", err.Error(),
-		)
+		log.Debugw("Failed to parse input", log.ErrorKey, err)
 		return nil, err
 	}
 	var parsedInput map[string]interface{}
@@ -20,4 +20,5 @@ type TaskResult struct {
 	Logs                             []TaskExecLog          `json:"logs,omitempty"`
 	ExternalOutputPayloadStoragePath string                 `json:"externalOutputPayloadStoragePath,omitempty"`
 	SubWorkflowId                    string                 `json:"subWorkflowId,omitempty"`
+	ExtendLease                      bool                   `json:"extendLease,omitempty"`
 }
@@ -9,9 +9,45 @@
 
 package settings
 
+import (
+	"net/http"
+	"time"
+)
+
 type HttpSettings struct {
 	BaseUrl string
 	Headers map[string]string
+
+	// Timeout is the time limit of each request, including reading the response body. Zero means the
+	// CONDUCTOR_CLIENT_HTTP_TIMEOUT environment variable, in seconds, or 30s when it is not set.
+	Timeout time.Duration
+	// ResponseHeaderTimeout is the time limit to receive the response headers once the request is sent. Zero means no
+	// limit besides Timeout.
+	ResponseHeaderTimeout time.Duration
+	// DialTimeout is the time limit to open a connection. Zero means 30s.
+	DialTimeout time.Duration
+	// KeepAlive is the interval between keep-alive probes of open connections. Zero means 30s.
+	KeepAlive time.Duration
+
+	// MaxIdleConns is the maximum number of idle connections kept open. Zero means 100.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept open to the server. Zero means 100.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost is the maximum number of connections to the server. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is the time after which idle connections are closed. Zero means they are kept open.
+	IdleConnTimeout time.Duration
+
+	// ProxyUrl is the URL of the proxy requests are sent through. When empty, the proxy is read from the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyUrl string
+	// TLS configures the TLS connections to the server.
+	TLS *TLSSettings
+
+	// Transport sends the requests, in place of the transport built from the settings above.
+	Transport http.RoundTripper
+	// HttpClient sends the requests, in place of the client built from the settings above.
+	HttpClient *http.Client
 }
 
 func NewHttpDefaultSettings() *HttpSettings {
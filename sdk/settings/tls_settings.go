@@ -0,0 +1,84 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package settings
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSSettings configures the TLS connections to the server. Certificates and keys can be given either as PEM files, or
+// as PEM encoded bytes.
+type TLSSettings struct {
+	// CACertFile and CACertPEM hold the certificate authorities the server certificate is verified against, in
+	// addition to the system ones.
+	CACertFile string
+	CACertPEM  []byte
+	// ClientCertFile and ClientKeyFile, or ClientCertPEM and ClientKeyPEM, hold the client certificate and its private
+	// key presented to the server for mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+	ClientCertPEM  []byte
+	ClientKeyPEM   []byte
+	// MinVersion is the minimum TLS version accepted, such as tls.VersionTLS12. Zero means TLS 1.2.
+	MinVersion uint16
+	// ServerName overrides the name the server certificate is verified against.
+	ServerName string
+	// InsecureSkipVerify disables the verification of the server certificate. It must only be used for testing.
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig returns the tls.Config built from the settings, loading its certificates.
+func (s *TLSSettings) NewTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         s.ServerName,
+		InsecureSkipVerify: s.InsecureSkipVerify,
+	}
+	if s.MinVersion != 0 {
+		config.MinVersion = s.MinVersion
+	}
+	caCertPEM := s.CACertPEM
+	if s.CACertFile != "" {
+		var err error
+		caCertPEM, err = os.ReadFile(s.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+	}
+	if len(caCertPEM) > 0 {
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM(caCertPEM) {
+			return nil, errors.New("no CA certificate found")
+		}
+		config.RootCAs = certPool
+	}
+	var certificate tls.Certificate
+	var err error
+	switch {
+	case s.ClientCertFile != "" || s.ClientKeyFile != "":
+		certificate, err = tls.LoadX509KeyPair(s.ClientCertFile, s.ClientKeyFile)
+	case len(s.ClientCertPEM) > 0 || len(s.ClientKeyPEM) > 0:
+		certificate, err = tls.X509KeyPair(s.ClientCertPEM, s.ClientKeyPEM)
+	default:
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	config.Certificates = []tls.Certificate{certificate}
+	return config, nil
+}
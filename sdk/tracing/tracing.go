@@ -0,0 +1,105 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+// Package tracing holds the OpenTelemetry instrumentation of the SDK. Spans are created with the global tracer
+// provider, and trace context is propagated with the global propagator, both set with the otel package. Until then,
+// tracing is a no-op.
+package tracing
+
+import (
+	"context"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/conductor-sdk/conductor-go"
+
+// TraceContextInputKey is the key of the trace context in the input of workflows and tasks. Workflows pass it on to
+// their tasks by mapping it in the task input, such as:
+//
+//	"_traceContext": "${workflow.input._traceContext}"
+const TraceContextInputKey = "_traceContext"
+
+// Tracer returns the tracer of the SDK.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// EndSpan ends the span, recording err if not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InjectIntoHeaders adds the trace context of ctx to the provided HTTP headers.
+func InjectIntoHeaders(ctx context.Context, headers propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, headers)
+}
+
+// InjectIntoInput returns the input with the trace context of ctx added under TraceContextInputKey. The input is
+// returned as is when ctx has no span, or when it can not be represented as a map.
+func InjectIntoInput(ctx context.Context, input interface{}) interface{} {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return input
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return input
+	}
+	var inputMap map[string]interface{}
+	switch typedInput := input.(type) {
+	case nil:
+		inputMap = map[string]interface{}{}
+	case map[string]interface{}:
+		inputMap = make(map[string]interface{}, len(typedInput)+1)
+		for key, value := range typedInput {
+			inputMap[key] = value
+		}
+	default:
+		converted, err := model.ConvertToMap(input)
+		if err != nil || converted == nil {
+			return input
+		}
+		inputMap = converted
+	}
+	traceContext := make(map[string]interface{}, len(carrier))
+	for key, value := range carrier {
+		traceContext[key] = value
+	}
+	inputMap[TraceContextInputKey] = traceContext
+	return inputMap
+}
+
+// ExtractFromInput returns ctx with the remote span context found under TraceContextInputKey in the input, if any.
+func ExtractFromInput(ctx context.Context, input map[string]interface{}) context.Context {
+	carrier := propagation.MapCarrier{}
+	switch traceContext := input[TraceContextInputKey].(type) {
+	case map[string]interface{}:
+		for key, value := range traceContext {
+			if value, ok := value.(string); ok {
+				carrier[key] = value
+			}
+		}
+	case map[string]string:
+		for key, value := range traceContext {
+			carrier[key] = value
+		}
+	default:
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,247 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type CircuitBreakerState string
+
+const (
+	// CircuitBreakerClosed is the state of a circuit breaker letting the task be polled.
+	CircuitBreakerClosed CircuitBreakerState = "CLOSED"
+	// CircuitBreakerOpen is the state of a circuit breaker which tripped, and stops the task from being polled.
+	CircuitBreakerOpen CircuitBreakerState = "OPEN"
+	// CircuitBreakerHalfOpen is the state of a circuit breaker after its cooldown, letting a single task be polled to
+	// decide whether to close again.
+	CircuitBreakerHalfOpen CircuitBreakerState = "HALF_OPEN"
+)
+
+// CircuitBreakerSettings holds the settings of the circuit breaker of a task.
+type CircuitBreakerSettings struct {
+	// FailureRateThreshold is the rate of failed executions, between 0 and 1, from which the circuit breaker trips.
+	FailureRateThreshold float64
+	// Window is the number of most recent executions the failure rate is computed on.
+	Window int
+	// MinimumExecutions is the number of executions in the window below which the circuit breaker does not trip.
+	MinimumExecutions int
+	// Cooldown is the time the circuit breaker stays open before letting a trial task be polled.
+	Cooldown time.Duration
+}
+
+// NewCircuitBreakerSettings returns settings tripping the circuit breaker when half of the last 20 executions, and at
+// least 10, failed, with a cooldown of 30 seconds.
+func NewCircuitBreakerSettings() *CircuitBreakerSettings {
+	return &CircuitBreakerSettings{
+		FailureRateThreshold: 0.5,
+		Window:               20,
+		MinimumExecutions:    10,
+		Cooldown:             30 * time.Second,
+	}
+}
+
+func (s *CircuitBreakerSettings) validate() error {
+	if s.FailureRateThreshold <= 0 || s.FailureRateThreshold > 1 {
+		return fmt.Errorf("failure rate threshold must be greater than 0 and at most 1")
+	}
+	if s.Window < 1 {
+		return fmt.Errorf("window must be positive")
+	}
+	if s.MinimumExecutions < 1 || s.MinimumExecutions > s.Window {
+		return fmt.Errorf("minimum executions must be positive and at most the window")
+	}
+	if s.Cooldown <= 0 {
+		return fmt.Errorf("cooldown must be positive")
+	}
+	return nil
+}
+
+// circuitBreaker tracks the outcome of the executions of a task, and stops the task from being polled once too many
+// of them fail.
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	taskName string
+	settings CircuitBreakerSettings
+	state    CircuitBreakerState
+	// outcomes is a ring buffer of the most recent executions, true for failed ones.
+	outcomes      []bool
+	next          int
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+	// trialTaskId is the id of the trial task once it was polled, so that the outcome of the tasks polled before the
+	// circuit breaker tripped is not taken for the one of the trial.
+	trialTaskId string
+}
+
+func newCircuitBreaker(taskName string, settings CircuitBreakerSettings) *circuitBreaker {
+	return &circuitBreaker{
+		taskName: taskName,
+		settings: settings,
+		state:    CircuitBreakerClosed,
+		outcomes: make([]bool, 0, settings.Window),
+	}
+}
+
+// allowPoll returns the maximum number of tasks which can be polled, negative for no maximum, or the time to wait
+// before polling again if no task can be polled. The wait is zero while the trial task runs, as the circuit breaker
+// may close at any time.
+func (b *circuitBreaker) allowPoll() (int, time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case CircuitBreakerOpen:
+		remaining := b.settings.Cooldown - time.Since(b.openedAt)
+		if remaining > 0 {
+			return 0, remaining
+		}
+		log.Infow("Circuit breaker is half-open, polling a trial task", log.TaskTypeKey, b.taskName)
+		b.state = CircuitBreakerHalfOpen
+		b.startTrial()
+		return 1, 0
+	case CircuitBreakerHalfOpen:
+		if b.trialInFlight {
+			return 0, 0
+		}
+		b.startTrial()
+		return 1, 0
+	default:
+		return -1, 0
+	}
+}
+
+func (b *circuitBreaker) startTrial() {
+	b.trialInFlight = true
+	b.trialTaskId = ""
+}
+
+// setTrialTask tags the task handed out by the poll for the trial task as the trial.
+func (b *circuitBreaker) setTrialTask(taskId string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.state == CircuitBreakerHalfOpen && b.trialInFlight && b.trialTaskId == "" {
+		b.trialTaskId = taskId
+	}
+}
+
+// releaseTrial lets another trial task be polled when the trial task, empty if the poll for it returned none, ended
+// without an outcome.
+func (b *circuitBreaker) releaseTrial(taskId string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.trialTaskId == taskId {
+		b.trialInFlight = false
+		b.trialTaskId = ""
+	}
+}
+
+// record records the outcome of the execution of the task. Once half-open, only the outcome of the trial task counts.
+func (b *circuitBreaker) record(taskId string, failed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case CircuitBreakerHalfOpen:
+		if !b.trialInFlight || b.trialTaskId == "" || b.trialTaskId != taskId {
+			return
+		}
+		b.trialInFlight = false
+		b.trialTaskId = ""
+		if failed {
+			b.open()
+		} else {
+			log.Infow("Circuit breaker is closed", log.TaskTypeKey, b.taskName)
+			b.state = CircuitBreakerClosed
+			b.outcomes = b.outcomes[:0]
+			b.next = 0
+			b.failures = 0
+		}
+	case CircuitBreakerClosed:
+		if len(b.outcomes) < b.settings.Window {
+			b.outcomes = append(b.outcomes, failed)
+		} else {
+			if b.outcomes[b.next] {
+				b.failures -= 1
+			}
+			b.outcomes[b.next] = failed
+			b.next = (b.next + 1) % b.settings.Window
+		}
+		if failed {
+			b.failures += 1
+		}
+		if len(b.outcomes) >= b.settings.MinimumExecutions &&
+			float64(b.failures)/float64(len(b.outcomes)) >= b.settings.FailureRateThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *circuitBreaker) open() {
+	log.Warnw("Circuit breaker is open, pausing polling", log.TaskTypeKey, b.taskName, "cooldown", b.settings.Cooldown)
+	b.state = CircuitBreakerOpen
+	b.openedAt = time.Now()
+}
+
+func (b *circuitBreaker) getState() CircuitBreakerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+// recordExecution records the outcome of the execution of the task once it ends, given its result, nil if the task
+// was not executed to completion. Failed results count as failures.
+func (b *circuitBreaker) recordExecution(taskId string, taskResult *model.TaskResult) {
+	if taskResult == nil {
+		b.releaseTrial(taskId)
+		return
+	}
+	b.record(taskId, taskResult.Status == model.FailedTask || taskResult.Status == model.FailedWithTerminalErrorTask)
+}
+
+// SetCircuitBreakerForTask sets a circuit breaker for the task with the provided taskName, which trips once the rate
+// of executions resulting in a failed task reaches the threshold of the settings. While open, the task is not polled,
+// which is reported by the task_paused metric, until the cooldown elapses and a single trial task is polled: the
+// circuit breaker closes if it succeeds, and opens again otherwise. Nil settings remove the circuit breaker.
+func (c *TaskRunner) SetCircuitBreakerForTask(taskName string, settings *CircuitBreakerSettings) error {
+	if settings != nil {
+		if err := settings.validate(); err != nil {
+			return err
+		}
+	}
+	c.circuitBreakerMutex.Lock()
+	defer c.circuitBreakerMutex.Unlock()
+	if settings == nil {
+		delete(c.circuitBreakerByTaskName, taskName)
+		return nil
+	}
+	c.circuitBreakerByTaskName[taskName] = newCircuitBreaker(taskName, *settings)
+	return nil
+}
+
+// GetCircuitBreakerStateForTask returns the state of the circuit breaker of the task with the provided taskName, which
+// is closed if the task has no circuit breaker.
+func (c *TaskRunner) GetCircuitBreakerStateForTask(taskName string) CircuitBreakerState {
+	breaker := c.getCircuitBreaker(taskName)
+	if breaker == nil {
+		return CircuitBreakerClosed
+	}
+	return breaker.getState()
+}
+
+func (c *TaskRunner) getCircuitBreaker(taskName string) *circuitBreaker {
+	c.circuitBreakerMutex.RLock()
+	defer c.circuitBreakerMutex.RUnlock()
+	return c.circuitBreakerByTaskName[taskName]
+}
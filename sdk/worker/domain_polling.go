@@ -0,0 +1,184 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// domainPolling holds the domains a task is polled in, either in fallback order or by weight.
+type domainPolling struct {
+	domains  []string
+	fallback bool
+	// weights and currentWeights implement a smooth weighted round-robin over the domains.
+	weights        []int
+	currentWeights []int
+}
+
+// nextDomain returns the domain of the next poll for weighted polling, spreading polls across domains in proportion to
+// their weights.
+func (d *domainPolling) nextDomain() string {
+	if len(d.domains) == 1 {
+		return d.domains[0]
+	}
+	total := 0
+	selected := 0
+	for i, weight := range d.weights {
+		d.currentWeights[i] += weight
+		total += weight
+		if d.currentWeights[i] > d.currentWeights[selected] {
+			selected = i
+		}
+	}
+	d.currentWeights[selected] -= total
+	return d.domains[selected]
+}
+
+// SetDomainForTask sets the domain in which workers running the task with the provided taskName poll for tasks.
+// An empty domain polls for tasks without domain.
+func (c *TaskRunner) SetDomainForTask(taskName string, domain string) {
+	c.setDomainPolling(taskName, &domainPolling{
+		domains: []string{domain},
+		weights: []int{1},
+	})
+}
+
+// SetWeightedDomainsForTask makes workers running the task with the provided taskName poll in several domains, each
+// poll going to a single domain, so that the number of polls of each domain is proportional to its weight. An empty
+// domain polls for tasks without domain. It can be called before starting the worker, in which case the domain the
+// worker is started with is ignored.
+func (c *TaskRunner) SetWeightedDomainsForTask(taskName string, weightByDomain map[string]int) error {
+	if len(weightByDomain) == 0 {
+		return fmt.Errorf("at least one domain is required")
+	}
+	domains := make([]string, 0, len(weightByDomain))
+	for domain, weight := range weightByDomain {
+		if weight < 1 {
+			return fmt.Errorf("weight of domain %s must be positive", domain)
+		}
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	weights := make([]int, len(domains))
+	for i, domain := range domains {
+		weights[i] = weightByDomain[domain]
+	}
+	c.setDomainPolling(taskName, &domainPolling{
+		domains:        domains,
+		weights:        weights,
+		currentWeights: make([]int, len(domains)),
+	})
+	return nil
+}
+
+// SetFallbackDomainsForTask makes workers running the task with the provided taskName poll in several domains in
+// order: each domain is only polled for the tasks the previous domains could not provide. As each domain is polled
+// in turn, the poll timeout applies to each of them. An empty domain polls for tasks without domain. It can be called
+// before starting the worker, in which case the domain the worker is started with is ignored.
+func (c *TaskRunner) SetFallbackDomainsForTask(taskName string, domains ...string) error {
+	if len(domains) == 0 {
+		return fmt.Errorf("at least one domain is required")
+	}
+	c.setDomainPolling(taskName, &domainPolling{
+		domains:  append([]string(nil), domains...),
+		fallback: true,
+	})
+	return nil
+}
+
+// GetDomainForTask retrieves the domain in which workers running the task with the provided taskName poll for tasks,
+// or the first one if they poll in several domains.
+func (c *TaskRunner) GetDomainForTask(taskName string) string {
+	domains := c.GetDomainsForTask(taskName)
+	if len(domains) == 0 {
+		return ""
+	}
+	return domains[0]
+}
+
+// GetDomainsForTask retrieves the domains in which workers running the task with the provided taskName poll for tasks.
+func (c *TaskRunner) GetDomainsForTask(taskName string) []string {
+	c.domainPollingMutex.RLock()
+	defer c.domainPollingMutex.RUnlock()
+	polling, ok := c.domainPollingByTaskName[taskName]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), polling.domains...)
+}
+
+func (c *TaskRunner) setDomainPolling(taskName string, polling *domainPolling) {
+	c.domainPollingMutex.Lock()
+	defer c.domainPollingMutex.Unlock()
+	c.domainPollingByTaskName[taskName] = polling
+}
+
+// setDomainIfAbsent sets the domain of the task unless domains were already set for it.
+func (c *TaskRunner) setDomainIfAbsent(taskName string, domain string) {
+	c.domainPollingMutex.Lock()
+	defer c.domainPollingMutex.Unlock()
+	if _, ok := c.domainPollingByTaskName[taskName]; ok {
+		return
+	}
+	c.domainPollingByTaskName[taskName] = &domainPolling{
+		domains: []string{domain},
+		weights: []int{1},
+	}
+}
+
+// getDomainsToPoll returns the domains to poll next, in order, and whether to fall back to the next one for the tasks
+// the previous one could not provide.
+func (c *TaskRunner) getDomainsToPoll(taskName string) ([]string, bool) {
+	c.domainPollingMutex.Lock()
+	defer c.domainPollingMutex.Unlock()
+	polling, ok := c.domainPollingByTaskName[taskName]
+	if !ok {
+		return []string{""}, false
+	}
+	if polling.fallback {
+		return polling.domains, true
+	}
+	return []string{polling.nextDomain()}, false
+}
+
+// pollDomains polls for up to count tasks in the domains of the task.
+func (c *TaskRunner) pollDomains(taskName string, count int) ([]model.Task, error) {
+	domains, fallback := c.getDomainsToPoll(taskName)
+	if !fallback {
+		return c.batchPoll(taskName, count, domains[0])
+	}
+	var tasks []model.Task
+	var lastErr error
+	for _, domain := range domains {
+		polled, err := c.batchPoll(taskName, count-len(tasks), domain)
+		if err != nil {
+			domainLog(taskName, domain).Warn("Failed to poll", log.ErrorKey, err)
+			lastErr = err
+			continue
+		}
+		tasks = append(tasks, polled...)
+		if len(tasks) >= count {
+			break
+		}
+	}
+	if len(tasks) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return tasks, nil
+}
+
+func formatDomains(domains []string) string {
+	return strings.Join(domains, ",")
+}
@@ -0,0 +1,99 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type executionPoolSettings struct {
+	poolSize  int
+	queueSize int
+}
+
+// executionPool runs the tasks polled for a task name on a fixed number of goroutines, fed by a bounded queue.
+type executionPool struct {
+	queue    chan pooledTask
+	capacity int
+}
+
+type pooledTask struct {
+	ctx  context.Context
+	task model.Task
+}
+
+// SetExecutionPoolForTask makes the worker of the provided task execute tasks on a fixed pool of poolSize goroutines
+// fed by a local queue of queueSize tasks, instead of a new goroutine per task. Polling is skipped while the pool and
+// its queue are full, which is reported by the task_execution_queue_full metric, and the batch size only caps the
+// number of tasks retrieved by each poll. It must be set before the worker is started.
+func (c *TaskRunner) SetExecutionPoolForTask(taskName string, poolSize int, queueSize int) error {
+	if poolSize < 1 {
+		return fmt.Errorf("poolSize value must be positive")
+	}
+	if queueSize < 0 {
+		return fmt.Errorf("queueSize can not be negative")
+	}
+	c.executionPoolMutex.Lock()
+	defer c.executionPoolMutex.Unlock()
+	c.executionPoolSettingsByTaskName[taskName] = executionPoolSettings{
+		poolSize:  poolSize,
+		queueSize: queueSize,
+	}
+	log.Infow("Set execution pool", log.TaskTypeKey, taskName, "poolSize", poolSize, "queueSize", queueSize)
+	return nil
+}
+
+// startExecutionPool starts the execution pool of the task, if one was set, which runs until the pool is closed.
+func (c *TaskRunner) startExecutionPool(taskName string, executeFunction model.ExecuteTaskFunctionWithContext) *executionPool {
+	c.executionPoolMutex.RLock()
+	settings, ok := c.executionPoolSettingsByTaskName[taskName]
+	c.executionPoolMutex.RUnlock()
+	if !ok {
+		return nil
+	}
+	pool := &executionPool{
+		queue:    make(chan pooledTask, settings.queueSize),
+		capacity: settings.poolSize + settings.queueSize,
+	}
+	for i := 0; i < settings.poolSize; i++ {
+		c.workerWaitGroup.Add(1)
+		go c.runExecutionPoolWorker(taskName, pool, executeFunction)
+	}
+	return pool
+}
+
+func (c *TaskRunner) runExecutionPoolWorker(taskName string, pool *executionPool, executeFunction model.ExecuteTaskFunctionWithContext) {
+	defer c.workerWaitGroup.Done()
+	defer concurrency.HandleTaskPanicError(taskName, "execution_pool")
+	for pooled := range pool.queue {
+		c.executeAndUpdateTask(pooled.ctx, taskName, pooled.task, executeFunction)
+	}
+}
+
+// getAvailableSlots returns the number of tasks which can be polled without exceeding the capacity of the pool.
+func (p *executionPool) getAvailableSlots(runningWorkers int) int {
+	return p.capacity - runningWorkers
+}
+
+func (p *executionPool) submit(ctx context.Context, task model.Task) {
+	p.queue <- pooledTask{
+		ctx:  ctx,
+		task: task,
+	}
+}
+
+func (p *executionPool) close() {
+	close(p.queue)
+}
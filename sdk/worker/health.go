@@ -0,0 +1,237 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+)
+
+// taskHealth holds the outcome of the latest polls and updates of a task.
+type taskHealth struct {
+	lastSuccessfulPoll  time.Time
+	lastPollError       string
+	lastPollErrorTime   time.Time
+	lastUpdateError     string
+	lastUpdateErrorTime time.Time
+}
+
+// TaskStatus is the status of the workers of a task, as reported by the handler returned by HealthHandler.
+type TaskStatus struct {
+	TaskName            string     `json:"taskName"`
+	Domains             []string   `json:"domains"`
+	WorkerId            string     `json:"workerId"`
+	BatchSize           int        `json:"batchSize"`
+	PollIntervalMs      int64      `json:"pollIntervalMs"`
+	Paused              bool       `json:"paused"`
+	CircuitBreaker      string     `json:"circuitBreaker"`
+	InFlight            int        `json:"inFlight"`
+	Ready               bool       `json:"ready"`
+	LastSuccessfulPoll  *time.Time `json:"lastSuccessfulPoll,omitempty"`
+	LastPollError       string     `json:"lastPollError,omitempty"`
+	LastPollErrorTime   *time.Time `json:"lastPollErrorTime,omitempty"`
+	LastUpdateError     string     `json:"lastUpdateError,omitempty"`
+	LastUpdateErrorTime *time.Time `json:"lastUpdateErrorTime,omitempty"`
+}
+
+// HealthStatus is the status of a TaskRunner, as reported by the handler returned by HealthHandler.
+type HealthStatus struct {
+	Live  bool         `json:"live"`
+	Ready bool         `json:"ready"`
+	Tasks []TaskStatus `json:"tasks"`
+}
+
+// GetHealthStatus returns the status of the TaskRunner and of the workers of every task. The TaskRunner is live until
+// it is shut down with ShutdownAll, and ready when it runs workers and every task which is neither paused nor stopped
+// by its circuit breaker polled successfully since its last failed poll.
+func (c *TaskRunner) GetHealthStatus() HealthStatus {
+	status := HealthStatus{
+		Live:  c.ctx.Err() == nil,
+		Tasks: make([]TaskStatus, 0),
+	}
+	runningWorkers := c.getRunningWorkersForAll()
+	for taskName, batchSize := range c.GetBatchSizeForAll() {
+		pollInterval, _ := c.GetPollIntervalForTask(taskName)
+		taskStatus := TaskStatus{
+			TaskName:       taskName,
+			Domains:        c.GetDomainsForTask(taskName),
+			WorkerId:       c.GetWorkerIdForTask(taskName),
+			BatchSize:      batchSize,
+			PollIntervalMs: pollInterval.Milliseconds(),
+			Paused:         c.isPaused(taskName),
+			CircuitBreaker: string(c.GetCircuitBreakerStateForTask(taskName)),
+			InFlight:       runningWorkers[taskName],
+		}
+		health := c.getTaskHealth(taskName)
+		if !health.lastSuccessfulPoll.IsZero() {
+			taskStatus.LastSuccessfulPoll = &health.lastSuccessfulPoll
+		}
+		if !health.lastPollErrorTime.IsZero() {
+			taskStatus.LastPollError = health.lastPollError
+			taskStatus.LastPollErrorTime = &health.lastPollErrorTime
+		}
+		if !health.lastUpdateErrorTime.IsZero() {
+			taskStatus.LastUpdateError = health.lastUpdateError
+			taskStatus.LastUpdateErrorTime = &health.lastUpdateErrorTime
+		}
+		taskStatus.Ready = taskStatus.Paused || taskStatus.CircuitBreaker != string(CircuitBreakerClosed) ||
+			(!health.lastSuccessfulPoll.IsZero() && health.lastSuccessfulPoll.After(health.lastPollErrorTime))
+		status.Tasks = append(status.Tasks, taskStatus)
+	}
+	sort.Slice(status.Tasks, func(i, j int) bool {
+		return status.Tasks[i].TaskName < status.Tasks[j].TaskName
+	})
+	status.Ready = status.Live && len(status.Tasks) > 0
+	for _, taskStatus := range status.Tasks {
+		status.Ready = status.Ready && taskStatus.Ready
+	}
+	return status
+}
+
+// HealthHandler returns an http.Handler reporting the health of the TaskRunner, which serves:
+//
+//	GET /live                         200 while the TaskRunner is live, 503 otherwise
+//	GET /ready                        200 while the TaskRunner is ready, 503 otherwise
+//	GET /workers                      the HealthStatus of the TaskRunner
+//
+// With enableControl, it also lets operators control the workers of a task with:
+//
+//	POST /workers/{taskName}/pause    pauses polling for the task
+//	POST /workers/{taskName}/resume   resumes polling for the task
+//	PUT  /workers/{taskName}/batchSize with a body such as {"batchSize": 5}, sets the batch size of the task
+//
+// The handler is meant to be mounted with http.StripPrefix on the server of the application.
+func (c *TaskRunner) HealthHandler(enableControl bool) http.Handler {
+	return &healthHandler{
+		taskRunner:    c,
+		enableControl: enableControl,
+	}
+}
+
+type healthHandler struct {
+	taskRunner    *TaskRunner
+	enableControl bool
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "live" && r.Method == http.MethodGet:
+		status := h.taskRunner.GetHealthStatus()
+		writeHealthResponse(w, status.Live, status)
+	case path == "ready" && r.Method == http.MethodGet:
+		status := h.taskRunner.GetHealthStatus()
+		writeHealthResponse(w, status.Ready, status)
+	case path == "workers" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, h.taskRunner.GetHealthStatus())
+	case strings.HasPrefix(path, "workers/") && h.enableControl:
+		h.serveControl(w, r, strings.TrimPrefix(path, "workers/"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *healthHandler) serveControl(w http.ResponseWriter, r *http.Request, path string) {
+	separator := strings.LastIndex(path, "/")
+	if separator < 1 {
+		http.NotFound(w, r)
+		return
+	}
+	taskName, action := path[:separator], path[separator+1:]
+	if !h.taskRunner.isWorkerRegistered(taskName) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("no worker registered for taskName: %s", taskName)})
+		return
+	}
+	switch {
+	case action == "pause" && r.Method == http.MethodPost:
+		log.Infow("Pausing task on remote request", log.TaskTypeKey, taskName)
+		h.taskRunner.Pause(taskName)
+	case action == "resume" && r.Method == http.MethodPost:
+		log.Infow("Resuming task on remote request", log.TaskTypeKey, taskName)
+		h.taskRunner.Resume(taskName)
+	case action == "batchSize" && r.Method == http.MethodPut:
+		var body struct {
+			BatchSize *int `json:"batchSize"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.BatchSize == nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "expected a body such as {\"batchSize\": 5}"})
+			return
+		}
+		log.Infow("Setting batch size on remote request", log.TaskTypeKey, taskName, "batchSize", *body.BatchSize)
+		if err := h.taskRunner.SetBatchSize(taskName, *body.BatchSize); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.taskRunner.GetHealthStatus())
+}
+
+func writeHealthResponse(w http.ResponseWriter, healthy bool, status HealthStatus) {
+	statusCode := http.StatusOK
+	if !healthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, statusCode, status)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Warnw("Failed to write health response", log.ErrorKey, err)
+	}
+}
+
+func (c *TaskRunner) getTaskHealth(taskName string) taskHealth {
+	c.healthMutex.RLock()
+	defer c.healthMutex.RUnlock()
+	if health, ok := c.healthByTaskName[taskName]; ok {
+		return *health
+	}
+	return taskHealth{}
+}
+
+func (c *TaskRunner) recordPoll(taskName string, err error) {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	health := c.getOrCreateTaskHealth(taskName)
+	if err != nil {
+		health.lastPollError = err.Error()
+		health.lastPollErrorTime = time.Now()
+	} else {
+		health.lastSuccessfulPoll = time.Now()
+	}
+}
+
+func (c *TaskRunner) recordUpdateError(taskName string, err error) {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	health := c.getOrCreateTaskHealth(taskName)
+	health.lastUpdateError = err.Error()
+	health.lastUpdateErrorTime = time.Now()
+}
+
+func (c *TaskRunner) getOrCreateTaskHealth(taskName string) *taskHealth {
+	health, ok := c.healthByTaskName[taskName]
+	if !ok {
+		health = &taskHealth{}
+		c.healthByTaskName[taskName] = health
+	}
+	return health
+}
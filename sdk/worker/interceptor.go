@@ -0,0 +1,97 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// ExecuteHandler executes a task and returns its result.
+type ExecuteHandler func(ctx context.Context, t *model.Task) *model.TaskResult
+
+// UpdateHandler sends the result of a task to Conductor, retrying on failures.
+type UpdateHandler func(ctx context.Context, t *model.Task, taskResult *model.TaskResult) error
+
+// ExecuteInterceptor runs around the execution of a task. It calls next to continue the chain, or short-circuits it by
+// returning a TaskResult of its own. It can also modify the task before, or the result after, calling next.
+type ExecuteInterceptor func(ctx context.Context, t *model.Task, next ExecuteHandler) *model.TaskResult
+
+// UpdateInterceptor runs around the update of a task result. It calls next to continue the chain, or skips the update
+// by returning without calling it.
+type UpdateInterceptor func(ctx context.Context, t *model.Task, taskResult *model.TaskResult, next UpdateHandler) error
+
+// AddExecuteInterceptor adds an interceptor which runs around the execution of every task. Interceptors added first
+// run outermost, and interceptors for all tasks run before the ones added for a specific task.
+func (c *TaskRunner) AddExecuteInterceptor(interceptor ExecuteInterceptor) {
+	c.interceptorsMutex.Lock()
+	defer c.interceptorsMutex.Unlock()
+	c.executeInterceptors = append(c.executeInterceptors, interceptor)
+}
+
+// AddExecuteInterceptorForTask adds an interceptor which runs around the execution of the task with the provided name.
+func (c *TaskRunner) AddExecuteInterceptorForTask(taskName string, interceptor ExecuteInterceptor) {
+	c.interceptorsMutex.Lock()
+	defer c.interceptorsMutex.Unlock()
+	c.executeInterceptorsByTaskName[taskName] = append(c.executeInterceptorsByTaskName[taskName], interceptor)
+}
+
+// AddUpdateInterceptor adds an interceptor which runs around the update of every task result. Interceptors added first
+// run outermost, and interceptors for all tasks run before the ones added for a specific task.
+func (c *TaskRunner) AddUpdateInterceptor(interceptor UpdateInterceptor) {
+	c.interceptorsMutex.Lock()
+	defer c.interceptorsMutex.Unlock()
+	c.updateInterceptors = append(c.updateInterceptors, interceptor)
+}
+
+// AddUpdateInterceptorForTask adds an interceptor which runs around the update of results for the task with the
+// provided name.
+func (c *TaskRunner) AddUpdateInterceptorForTask(taskName string, interceptor UpdateInterceptor) {
+	c.interceptorsMutex.Lock()
+	defer c.interceptorsMutex.Unlock()
+	c.updateInterceptorsByTaskName[taskName] = append(c.updateInterceptorsByTaskName[taskName], interceptor)
+}
+
+func (c *TaskRunner) getExecuteInterceptors(taskName string) []ExecuteInterceptor {
+	c.interceptorsMutex.RLock()
+	defer c.interceptorsMutex.RUnlock()
+	interceptors := make([]ExecuteInterceptor, 0, len(c.executeInterceptors)+len(c.executeInterceptorsByTaskName[taskName]))
+	interceptors = append(interceptors, c.executeInterceptors...)
+	return append(interceptors, c.executeInterceptorsByTaskName[taskName]...)
+}
+
+func (c *TaskRunner) getUpdateInterceptors(taskName string) []UpdateInterceptor {
+	c.interceptorsMutex.RLock()
+	defer c.interceptorsMutex.RUnlock()
+	interceptors := make([]UpdateInterceptor, 0, len(c.updateInterceptors)+len(c.updateInterceptorsByTaskName[taskName]))
+	interceptors = append(interceptors, c.updateInterceptors...)
+	return append(interceptors, c.updateInterceptorsByTaskName[taskName]...)
+}
+
+func chainExecuteInterceptors(interceptors []ExecuteInterceptor, handler ExecuteHandler) ExecuteHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, t *model.Task) *model.TaskResult {
+			return interceptor(ctx, t, next)
+		}
+	}
+	return handler
+}
+
+func chainUpdateInterceptors(interceptors []UpdateInterceptor, handler UpdateHandler) UpdateHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, t *model.Task, taskResult *model.TaskResult) error {
+			return interceptor(ctx, t, taskResult, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,127 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+)
+
+// PollBackoff makes the wait between polls grow exponentially while polls for a task return no tasks or fail. The
+// wait after an empty poll starts at the poll interval of the task, the wait after a failed poll starts at the sleep on
+// generic error of the TaskRunner, and both are reset as soon as tasks are polled.
+type PollBackoff struct {
+	// Multiplier is the factor by which the wait grows after each consecutive empty or failed poll.
+	Multiplier float64
+	// MaxInterval caps the wait between polls.
+	MaxInterval time.Duration
+}
+
+// NewPollBackoff returns a PollBackoff growing by multiplier up to maxInterval.
+func NewPollBackoff(multiplier float64, maxInterval time.Duration) *PollBackoff {
+	return &PollBackoff{
+		Multiplier:  multiplier,
+		MaxInterval: maxInterval,
+	}
+}
+
+func (b *PollBackoff) getInterval(baseInterval time.Duration, consecutiveMisses int) time.Duration {
+	if b == nil || consecutiveMisses < 2 || b.Multiplier <= 1 {
+		return baseInterval
+	}
+	interval := float64(baseInterval) * math.Pow(b.Multiplier, float64(consecutiveMisses-1))
+	if b.MaxInterval > 0 && interval > float64(b.MaxInterval) {
+		return b.MaxInterval
+	}
+	return time.Duration(interval)
+}
+
+// pollBackoffState counts the consecutive polls of a task which returned no tasks, or failed.
+type pollBackoffState struct {
+	emptyPolls  int
+	failedPolls int
+}
+
+// SetPollBackoff sets the backoff applied to the wait between polls of all tasks. Nil disables it, which is the
+// default, so that tasks are polled every poll interval.
+func (c *TaskRunner) SetPollBackoff(backoff *PollBackoff) {
+	c.pollBackoffMutex.Lock()
+	defer c.pollBackoffMutex.Unlock()
+	c.pollBackoff = backoff
+	log.Infow("Updated poll backoff", "pollBackoff", formatPollBackoff(backoff))
+}
+
+// SetPollBackoffForTask sets the backoff applied to the wait between polls of the task with the provided name,
+// overriding the one set with SetPollBackoff.
+func (c *TaskRunner) SetPollBackoffForTask(taskName string, backoff *PollBackoff) {
+	c.pollBackoffMutex.Lock()
+	defer c.pollBackoffMutex.Unlock()
+	c.pollBackoffByTaskName[taskName] = backoff
+	log.Infow("Updated poll backoff", log.TaskTypeKey, taskName, "pollBackoff", formatPollBackoff(backoff))
+}
+
+// GetPollBackoffForTask returns the backoff applied to the wait between polls of the task with the provided name.
+func (c *TaskRunner) GetPollBackoffForTask(taskName string) *PollBackoff {
+	c.pollBackoffMutex.RLock()
+	defer c.pollBackoffMutex.RUnlock()
+	backoff, ok := c.pollBackoffByTaskName[taskName]
+	if !ok {
+		return c.pollBackoff
+	}
+	return backoff
+}
+
+// getIntervalAfterEmptyPoll records an empty poll for the task and returns the time to wait before polling again.
+func (c *TaskRunner) getIntervalAfterEmptyPoll(taskName string, pollInterval time.Duration) time.Duration {
+	c.pollBackoffMutex.Lock()
+	state := c.getPollBackoffState(taskName)
+	state.emptyPolls += 1
+	state.failedPolls = 0
+	emptyPolls := state.emptyPolls
+	c.pollBackoffMutex.Unlock()
+	return c.GetPollBackoffForTask(taskName).getInterval(pollInterval, emptyPolls)
+}
+
+// getIntervalAfterFailedPoll records a failed poll for the task and returns the time to wait before polling again.
+func (c *TaskRunner) getIntervalAfterFailedPoll(taskName string) time.Duration {
+	c.pollBackoffMutex.Lock()
+	state := c.getPollBackoffState(taskName)
+	state.failedPolls += 1
+	state.emptyPolls = 0
+	failedPolls := state.failedPolls
+	c.pollBackoffMutex.Unlock()
+	return c.GetPollBackoffForTask(taskName).getInterval(c.getSleepOnGenericError(), failedPolls)
+}
+
+// resetPollBackoff resets the backoff of the task, once tasks were polled.
+func (c *TaskRunner) resetPollBackoff(taskName string) {
+	c.pollBackoffMutex.Lock()
+	defer c.pollBackoffMutex.Unlock()
+	delete(c.pollBackoffStateByTaskName, taskName)
+}
+
+func (c *TaskRunner) getPollBackoffState(taskName string) *pollBackoffState {
+	state, ok := c.pollBackoffStateByTaskName[taskName]
+	if !ok {
+		state = &pollBackoffState{}
+		c.pollBackoffStateByTaskName[taskName] = state
+	}
+	return state
+}
+
+func formatPollBackoff(backoff *PollBackoff) string {
+	if backoff == nil {
+		return "disabled"
+	}
+	return fmt.Sprintf("multiplier %v, max interval %dms", backoff.Multiplier, backoff.MaxInterval.Milliseconds())
+}
@@ -0,0 +1,97 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a token bucket holding up to burst tokens, refilled at rate tokens per second. Each polled task takes
+// a token.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// take takes up to max whole tokens from the bucket, and returns the number of tokens taken.
+func (b *tokenBucket) take(max int) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	taken := int(math.Min(float64(max), math.Floor(b.tokens)))
+	b.tokens -= float64(taken)
+	return taken
+}
+
+// giveBack returns tokens taken but not used to the bucket.
+func (b *tokenBucket) giveBack(tokens int) {
+	if tokens < 1 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	b.tokens = math.Min(b.burst, b.tokens+float64(tokens))
+}
+
+// timeUntilNextToken returns the time until a whole token is available in the bucket.
+func (b *tokenBucket) timeUntilNextToken() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// SetRateLimitForTask limits the rate at which tasks with the provided taskName are polled to tasksPerSecond, allowing
+// bursts of up to burst tasks. Polling is skipped while the limit is reached, so that tasks are left in the queue
+// rather than polled and held locally. A non-positive tasksPerSecond removes the limit.
+func (c *TaskRunner) SetRateLimitForTask(taskName string, tasksPerSecond float64, burst int) error {
+	if tasksPerSecond > 0 && burst < 1 {
+		return fmt.Errorf("burst must be positive")
+	}
+	c.rateLimiterMutex.Lock()
+	defer c.rateLimiterMutex.Unlock()
+	if tasksPerSecond <= 0 {
+		delete(c.rateLimiterByTaskName, taskName)
+		return nil
+	}
+	c.rateLimiterByTaskName[taskName] = newTokenBucket(tasksPerSecond, burst)
+	return nil
+}
+
+func (c *TaskRunner) getRateLimiter(taskName string) *tokenBucket {
+	c.rateLimiterMutex.RLock()
+	defer c.rateLimiterMutex.RUnlock()
+	return c.rateLimiterByTaskName[taskName]
+}
@@ -0,0 +1,167 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// WorkerOptions holds the options of a worker started with StartWorkerWithOptions.
+type WorkerOptions struct {
+	// Domain is the domain in which the worker polls for tasks. Empty means no domain.
+	Domain string
+	// TaskDef, when set, is registered before polling starts if the task has no definition on the server. Otherwise,
+	// the fields of the definition on the server which diverge from it are updated, and its other fields are kept. An
+	// empty name defaults to the name of the task.
+	TaskDef *model.TaskDef
+	// Tags are registered along with TaskDef. When nil, the tags of the definition on the server are kept.
+	Tags []model.MetadataTag
+	// StrictTaskDef makes startup fail, instead of updating the definition on the server, if it diverges from TaskDef.
+	StrictTaskDef bool
+}
+
+// StartWorkerWithOptions behaves like StartWorkerWithContext, but first registers the task definition of the options,
+// if any. No worker is started if the registration fails.
+//
+// The definition on the server diverges from TaskDef if any of its fields set in TaskDef, or its retryCount and
+// timeoutSeconds, which are always sent, have a different value, or if it does not have the same tags. Fields left
+// unset in TaskDef are filled with defaults by the server and never considered divergent.
+func (c *TaskRunner) StartWorkerWithOptions(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, batchSize int, pollInterval time.Duration, options WorkerOptions) error {
+	if options.TaskDef != nil {
+		err := c.registerTaskDef(c.ctx, taskName, options)
+		if err != nil {
+			return err
+		}
+	}
+	return c.startWorker(taskName, executeFunction, batchSize, pollInterval, options.Domain)
+}
+
+func (c *TaskRunner) registerTaskDef(ctx context.Context, taskName string, options WorkerOptions) error {
+	taskDef := *options.TaskDef
+	if taskDef.Name == "" {
+		taskDef.Name = taskName
+	}
+	if taskDef.Name != taskName {
+		return fmt.Errorf("task definition %s does not match task %s", taskDef.Name, taskName)
+	}
+	serverTaskDef, _, err := c.metadataClient.GetTaskDef(ctx, taskName)
+	if err != nil {
+		if !errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("failed to get task definition %s: %w", taskName, err)
+		}
+		log.Infow("Registering task definition", log.TaskTypeKey, taskName)
+		_, err = c.metadataClient.RegisterTaskDefWithTags(ctx, taskDef, options.Tags)
+		if err != nil {
+			return fmt.Errorf("failed to register task definition %s: %w", taskName, err)
+		}
+		return nil
+	}
+	mergedTaskDef, divergentFields, err := mergeTaskDef(taskDef, serverTaskDef)
+	if err != nil {
+		return err
+	}
+	if options.Tags != nil {
+		serverTags, err := c.metadataClient.GetTagsForTaskDef(ctx, taskName)
+		if err != nil {
+			return fmt.Errorf("failed to get tags of task definition %s: %w", taskName, err)
+		}
+		if !equalTags(options.Tags, serverTags) {
+			divergentFields = append(divergentFields, "tags")
+		}
+	}
+	if len(divergentFields) == 0 {
+		return nil
+	}
+	if options.StrictTaskDef {
+		return fmt.Errorf("task definition %s diverges from the server on: %s", taskName, strings.Join(divergentFields, ", "))
+	}
+	log.Infow("Updating task definition", log.TaskTypeKey, taskName, "divergentFields", divergentFields)
+	// Tags are only overwritten when set, so that the tags of the definition on the server are kept otherwise
+	_, err = c.metadataClient.UpdateTaskDefWithTags(ctx, mergedTaskDef, options.Tags, options.Tags != nil)
+	if err != nil {
+		return fmt.Errorf("failed to update task definition %s: %w", taskName, err)
+	}
+	return nil
+}
+
+// mergeTaskDef returns the definition on the server updated with the fields of the desired task definition which
+// differ, along with their json names. Fields managed by the server, and fields which are omitted from the json when
+// unset, are ignored if not set, so that their value on the server is kept.
+func mergeTaskDef(desired model.TaskDef, actual model.TaskDef) (model.TaskDef, []string, error) {
+	// Round trip the desired definition, so that values such as numbers in the input template compare as decoded
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return model.TaskDef{}, nil, err
+	}
+	desired = model.TaskDef{}
+	err = json.Unmarshal(data, &desired)
+	if err != nil {
+		return model.TaskDef{}, nil, err
+	}
+	merged := actual
+	desiredValue := reflect.ValueOf(desired)
+	actualValue := reflect.ValueOf(actual)
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	divergentFields := make([]string, 0)
+	for i := 0; i < desiredValue.NumField(); i++ {
+		field := desiredValue.Type().Field(i)
+		name, flags, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if serverManagedTaskDefFields[name] {
+			continue
+		}
+		if flags == "omitempty" && desiredValue.Field(i).IsZero() {
+			continue
+		}
+		if !reflect.DeepEqual(desiredValue.Field(i).Interface(), actualValue.Field(i).Interface()) {
+			divergentFields = append(divergentFields, name)
+			mergedValue.Field(i).Set(desiredValue.Field(i))
+		}
+	}
+	return merged, divergentFields, nil
+}
+
+var serverManagedTaskDefFields = map[string]bool{
+	"ownerApp":      true,
+	"createTime":    true,
+	"updateTime":    true,
+	"createdBy":     true,
+	"updatedBy":     true,
+	"name":          true,
+	"tags":          true,
+	"overwriteTags": true,
+}
+
+func equalTags(desired []model.MetadataTag, actual []model.MetadataTag) bool {
+	if len(desired) != len(actual) {
+		return false
+	}
+	sortTags := func(tags []model.MetadataTag) []model.MetadataTag {
+		sorted := append([]model.MetadataTag(nil), tags...)
+		sort.Slice(sorted, func(i, j int) bool {
+			if sorted[i].Key != sorted[j].Key {
+				return sorted[i].Key < sorted[j].Key
+			}
+			return sorted[i].Value < sorted[j].Value
+		})
+		return sorted
+	}
+	return reflect.DeepEqual(sortTags(desired), sortTags(actual))
+}
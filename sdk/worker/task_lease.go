@@ -0,0 +1,143 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// leaseExtensionFactor is the fraction of the response timeout of a task after which its lease is extended.
+const leaseExtensionFactor = 0.8
+
+type taskLeaseKey struct{}
+
+// TaskLease is the handle through which a running worker attaches interim output and logs to the periodic updates
+// extending the lease of its task. It is available to workers started with a context, for tasks with lease extension
+// enabled, through GetTaskLease.
+type TaskLease struct {
+	mutex      sync.Mutex
+	task       *model.Task
+	outputData map[string]interface{}
+	logger     *TaskLogger
+}
+
+// GetTaskLease returns the lease of the task being executed with the provided context, or nil if the lease of the task
+// is not extended.
+func GetTaskLease(ctx context.Context) *TaskLease {
+	lease, _ := ctx.Value(taskLeaseKey{}).(*TaskLease)
+	return lease
+}
+
+// SetOutputData sets the interim output sent with the next lease extensions. The output of the task once it completes
+// is the one returned by the worker.
+func (l *TaskLease) SetOutputData(outputData map[string]interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.outputData = outputData
+}
+
+// Log adds an execution log sent with the next lease extension, or with the result of the task if it completes first.
+// It is equivalent to logging with the TaskLogger of the task.
+func (l *TaskLease) Log(message string) {
+	l.logger.Log(message)
+}
+
+func newTaskLease(t *model.Task, logger *TaskLogger) *TaskLease {
+	return &TaskLease{
+		task:   t,
+		logger: logger,
+	}
+}
+
+func withTaskLease(ctx context.Context, lease *TaskLease) context.Context {
+	return context.WithValue(ctx, taskLeaseKey{}, lease)
+}
+
+// newLeaseExtensionResult returns an IN_PROGRESS result extending the lease of the task, carrying the pending logs.
+func (l *TaskLease) newLeaseExtensionResult() *model.TaskResult {
+	taskResult := model.NewTaskResultFromTask(l.task)
+	taskResult.Status = model.InProgressTask
+	taskResult.ExtendLease = true
+	l.mutex.Lock()
+	taskResult.OutputData = l.outputData
+	l.mutex.Unlock()
+	taskResult.Logs = l.logger.drain()
+	return taskResult
+}
+
+func getLeaseExtensionInterval(t *model.Task) time.Duration {
+	return time.Duration(float64(t.ResponseTimeoutSeconds) * leaseExtensionFactor * float64(time.Second))
+}
+
+// extendLeaseWhileRunning extends the lease of the task every interval, until the returned function is called, which
+// waits for any lease extension in progress so that it can not overtake the result of the task. The logs of a lease
+// extension which could not be sent are kept for the next update.
+func (c *TaskRunner) extendLeaseWhileRunning(taskName string, lease *TaskLease, interval time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		defer concurrency.HandleTaskPanicError(taskName, "extend_lease")
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				taskResult := lease.newLeaseExtensionResult()
+				taskResult.WorkerId = c.GetWorkerIdForTask(taskName)
+				if _, err := c.updateTask(taskName, taskResult); err != nil {
+					c.getMetrics().IncrementTaskUpdateError(taskName, err)
+					executionLog(lease.task).Warn("Failed to extend lease of task", log.ErrorKey, err)
+					lease.logger.restore(taskResult.Logs)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// SetLeaseExtension enables or disables lease extension for all tasks. While the worker of a task with lease extension
+// enabled is running, an IN_PROGRESS update extending the lease of the task is sent whenever 80% of its
+// responseTimeoutSeconds elapses, and the response timeout no longer cancels the context of the worker.
+func (c *TaskRunner) SetLeaseExtension(enabled bool) {
+	c.leaseExtensionMutex.Lock()
+	defer c.leaseExtensionMutex.Unlock()
+	c.leaseExtension = enabled
+}
+
+// SetLeaseExtensionForTask enables or disables lease extension for the task with the provided name, overriding the
+// setting for all tasks.
+func (c *TaskRunner) SetLeaseExtensionForTask(taskName string, enabled bool) {
+	c.leaseExtensionMutex.Lock()
+	defer c.leaseExtensionMutex.Unlock()
+	c.leaseExtensionByTaskName[taskName] = enabled
+}
+
+// IsLeaseExtensionEnabledForTask returns whether the lease of the task with the provided name is extended.
+func (c *TaskRunner) IsLeaseExtensionEnabledForTask(taskName string) bool {
+	c.leaseExtensionMutex.RLock()
+	defer c.leaseExtensionMutex.RUnlock()
+	enabled, ok := c.leaseExtensionByTaskName[taskName]
+	if !ok {
+		return c.leaseExtension
+	}
+	return enabled
+}
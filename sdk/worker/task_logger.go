@@ -0,0 +1,136 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type taskLoggerKey struct{}
+
+// TaskLogger buffers execution logs of a task, shown in the Conductor UI. Buffered logs are attached to the result of
+// the task once the worker returns, or sent to the server while the worker runs if a log flush interval is set with
+// SetTaskLogFlushInterval. It is available to workers started with a context through GetTaskLogger.
+type TaskLogger struct {
+	mutex sync.Mutex
+	task  *model.Task
+	logs  []model.TaskExecLog
+}
+
+// GetTaskLogger returns the logger of the task being executed with the provided context, or nil if the context does
+// not belong to a task execution.
+func GetTaskLogger(ctx context.Context) *TaskLogger {
+	logger, _ := ctx.Value(taskLoggerKey{}).(*TaskLogger)
+	return logger
+}
+
+// Log buffers a log line, timestamped with the current time.
+func (l *TaskLogger) Log(message string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.logs = append(l.logs, model.TaskExecLog{
+		Log:         message,
+		TaskId:      l.task.TaskId,
+		CreatedTime: time.Now().UnixMilli(),
+	})
+}
+
+// Logf buffers a log line formatted according to a format specifier, timestamped with the current time.
+func (l *TaskLogger) Logf(format string, args ...interface{}) {
+	l.Log(fmt.Sprintf(format, args...))
+}
+
+func newTaskLogger(t *model.Task) *TaskLogger {
+	return &TaskLogger{
+		task: t,
+	}
+}
+
+func withTaskLogger(ctx context.Context, logger *TaskLogger) context.Context {
+	return context.WithValue(ctx, taskLoggerKey{}, logger)
+}
+
+// drain returns the buffered logs and empties the buffer.
+func (l *TaskLogger) drain() []model.TaskExecLog {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	logs := l.logs
+	l.logs = nil
+	return logs
+}
+
+// restore puts logs which could not be sent back in front of the buffer.
+func (l *TaskLogger) restore(logs []model.TaskExecLog) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.logs = append(logs, l.logs...)
+}
+
+// flushTaskLogsWhileRunning sends the buffered logs of the task every interval, until the returned function is called,
+// which waits for any flush in progress. No logs are sent while the task runs if the interval is not positive.
+func (c *TaskRunner) flushTaskLogsWhileRunning(taskName string, logger *TaskLogger, interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		defer concurrency.HandleTaskPanicError(taskName, "flush_task_logs")
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				c.flushTaskLogs(logger)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (c *TaskRunner) flushTaskLogs(logger *TaskLogger) {
+	logs := logger.drain()
+	for i, taskLog := range logs {
+		_, err := c.conductorTaskResourceClient.Log(c.ctx, taskLog.Log, taskLog.TaskId)
+		if err != nil {
+			executionLog(logger.task).Warn("Failed to send logs of task", log.ErrorKey, err)
+			logger.restore(logs[i:])
+			return
+		}
+	}
+}
+
+// SetTaskLogFlushInterval sets the interval at which logs of running tasks are sent to the server. By default, and
+// with a zero interval, logs are only attached to the result of the task.
+func (c *TaskRunner) SetTaskLogFlushInterval(interval time.Duration) {
+	c.taskLogFlushIntervalMutex.Lock()
+	defer c.taskLogFlushIntervalMutex.Unlock()
+	c.taskLogFlushInterval = interval
+}
+
+// GetTaskLogFlushInterval returns the interval at which logs of running tasks are sent to the server.
+func (c *TaskRunner) GetTaskLogFlushInterval() time.Duration {
+	c.taskLogFlushIntervalMutex.RLock()
+	defer c.taskLogFlushIntervalMutex.RUnlock()
+	return c.taskLogFlushInterval
+}
@@ -0,0 +1,119 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// PendingTaskResult is a task result which could not be delivered to Conductor.
+type PendingTaskResult struct {
+	TaskName   string            `json:"taskName"`
+	TaskResult *model.TaskResult `json:"taskResult"`
+}
+
+// TaskResultStore keeps the task results which could not be delivered to Conductor, so that they are replayed once
+// the server is reachable again. Saving a result for a task replaces the one previously saved for that task.
+type TaskResultStore interface {
+	Save(pending PendingTaskResult) error
+	List() ([]PendingTaskResult, error)
+	Remove(taskId string) error
+}
+
+// FileTaskResultStore is a TaskResultStore which keeps each pending result as a JSON file in a directory.
+type FileTaskResultStore struct {
+	mutex     sync.Mutex
+	directory string
+}
+
+// NewFileTaskResultStore returns a FileTaskResultStore writing to the provided directory, which is created if needed.
+func NewFileTaskResultStore(directory string) (*FileTaskResultStore, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileTaskResultStore{
+		directory: directory,
+	}, nil
+}
+
+func (s *FileTaskResultStore) Save(pending PendingTaskResult) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	path := s.getPath(pending.TaskResult.TaskId)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// List returns the pending results which can be read. Files which can not be read are skipped, and files which can
+// not be decoded are renamed with a .corrupt suffix, so that they are neither replayed nor listed again.
+func (s *FileTaskResultStore) List() ([]PendingTaskResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, err
+	}
+	pendingResults := make([]PendingTaskResult, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(s.directory, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Warnw("Skipping unreadable pending task result", "path", path, log.ErrorKey, err)
+			continue
+		}
+		var pending PendingTaskResult
+		err = json.Unmarshal(data, &pending)
+		if err == nil && pending.TaskResult == nil {
+			err = fmt.Errorf("no task result")
+		}
+		if err != nil {
+			log.Errorw("Quarantining corrupt pending task result", "path", path, log.ErrorKey, err)
+			if err := os.Rename(path, path+".corrupt"); err != nil {
+				log.Warnw("Failed to quarantine corrupt pending task result", "path", path, log.ErrorKey, err)
+			}
+			continue
+		}
+		pendingResults = append(pendingResults, pending)
+	}
+	return pendingResults, nil
+}
+
+func (s *FileTaskResultStore) Remove(taskId string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	err := os.Remove(s.getPath(taskId))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileTaskResultStore) getPath(taskId string) string {
+	return filepath.Join(s.directory, url.PathEscape(taskId)+".json")
+}
@@ -11,34 +11,36 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"os"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	"github.com/conductor-sdk/conductor-go/sdk/client"
 	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/metrics"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
 
 	"github.com/antihax/optional"
-	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-const taskUpdateRetryAttemptsLimit = 3
-
-var (
-	sleepForOnNoAvailableWorker = 10 * time.Millisecond
-	sleepForOnGenericError      = 200 * time.Millisecond
+const (
+	sleepForOnNoAvailableWorker   = 10 * time.Millisecond
+	defaultSleepForOnGenericError = 200 * time.Millisecond
 )
 
-var hostname, _ = os.Hostname()
-
 // TaskRunner implements polling and execution logic for a Conductor worker. Every polling interval, each running
-// task attempts to retrieve a from Conductor. Multiple tasks can be started in parallel. All Goroutines started by this
-// worker cannot be stopped, only paused and resumed.
+// task attempts to retrieve a from Conductor. Multiple tasks can be started in parallel. Polling goroutines are paused
+// and resumed with Pause and Resume, and stopped with Shutdown, which also cancels the context handed to workers
+// started with StartWorkerWithContext.
 //
 // Conductor tasks are tracked by name separately. Each TaskRunner tracks a separate poll interval and batch size for
 // each task, which is shared by all workers running that task. For instance, if task "foo" is running with a batch size
@@ -47,6 +49,7 @@ var hostname, _ = os.Hostname()
 // All methods on TaskRunner are thread-safe.
 type TaskRunner struct {
 	conductorTaskResourceClient *client.TaskResourceApiService
+	metadataClient              client.MetadataClient
 
 	workerWaitGroup sync.WaitGroup
 
@@ -62,9 +65,85 @@ type TaskRunner struct {
 	pausedWorkersMutex sync.RWMutex
 	pausedWorkers      map[string]bool
 
+	domainPollingMutex      sync.RWMutex
+	domainPollingByTaskName map[string]*domainPolling
+
+	workerIdMutex    sync.RWMutex
+	workerIdTemplate string
+
 	pollTimeoutMutex      sync.RWMutex
 	pollTimeout           time.Duration
 	pollTimeoutByTaskName map[string]time.Duration
+
+	panicTaskResultStatusMutex sync.RWMutex
+	panicTaskResultStatus      model.TaskResultStatus
+
+	taskLogFlushIntervalMutex sync.RWMutex
+	taskLogFlushInterval      time.Duration
+
+	leaseExtensionMutex      sync.RWMutex
+	leaseExtension           bool
+	leaseExtensionByTaskName map[string]bool
+
+	interceptorsMutex             sync.RWMutex
+	executeInterceptors           []ExecuteInterceptor
+	executeInterceptorsByTaskName map[string][]ExecuteInterceptor
+	updateInterceptors            []UpdateInterceptor
+	updateInterceptorsByTaskName  map[string][]UpdateInterceptor
+
+	updateRetryPolicyMutex sync.RWMutex
+	updateRetryPolicy      UpdateRetryPolicy
+
+	taskResultStoreMutex sync.RWMutex
+	taskResultStore      TaskResultStore
+	stopReplay           context.CancelFunc
+
+	updateBatcherMutex sync.RWMutex
+	updateBatcher      *updateBatcher
+	stopUpdateBatcher  context.CancelFunc
+
+	sleepOnGenericErrorMutex sync.RWMutex
+	sleepOnGenericError      time.Duration
+
+	pollBackoffMutex           sync.RWMutex
+	pollBackoff                *PollBackoff
+	pollBackoffByTaskName      map[string]*PollBackoff
+	pollBackoffStateByTaskName map[string]*pollBackoffState
+
+	healthMutex      sync.RWMutex
+	healthByTaskName map[string]*taskHealth
+
+	workerConfigMutex      sync.RWMutex
+	workerConfig           map[string]taskWorkerConfig
+	stopWorkerConfigReload context.CancelFunc
+
+	circuitBreakerMutex      sync.RWMutex
+	circuitBreakerByTaskName map[string]*circuitBreaker
+
+	rateLimiterMutex      sync.RWMutex
+	rateLimiterByTaskName map[string]*tokenBucket
+
+	executionPoolMutex              sync.RWMutex
+	executionPoolSettingsByTaskName map[string]executionPoolSettings
+
+	metricsMutex sync.RWMutex
+	metrics      *metrics.Metrics
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	workerContextByTaskNameMutex sync.RWMutex
+	workerContextByTaskName      map[string]*workerContext
+}
+
+// workerContext holds the contexts shared by all workers of a task.
+type workerContext struct {
+	// pollCtx is cancelled once polling stops for the task, interrupting the waits between polls.
+	pollCtx     context.Context
+	stopPolling context.CancelFunc
+	// ctx is handed to every execution of the task, cancelled when the task is shut down.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewTaskRunner returns a new TaskRunner which authenticates via HTTP using the provided settings.
@@ -81,16 +160,36 @@ func NewTaskRunner(authenticationSettings *settings.AuthenticationSettings, http
 func NewTaskRunnerWithApiClient(
 	apiClient *client.APIClient,
 ) *TaskRunner {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &TaskRunner{
 		conductorTaskResourceClient: &client.TaskResourceApiService{
 			APIClient: apiClient,
 		},
+		metadataClient:           client.NewMetadataClient(apiClient),
 		batchSizeByTaskName:      make(map[string]int),
 		runningWorkersByTaskName: make(map[string]int),
 		pollIntervalByTaskName:   make(map[string]time.Duration),
 		pausedWorkers:            make(map[string]bool),
+		domainPollingByTaskName:  make(map[string]*domainPolling),
+		workerIdTemplate:         mustResolveWorkerIdTemplate(defaultWorkerIdTemplate),
 		pollTimeoutByTaskName:    make(map[string]time.Duration),
 		pollTimeout:              -1 * time.Millisecond, //If negative, the server will use its default.
+		panicTaskResultStatus:    model.FailedTask,
+		leaseExtensionByTaskName: make(map[string]bool),
+
+		executeInterceptorsByTaskName:   make(map[string][]ExecuteInterceptor),
+		updateInterceptorsByTaskName:    make(map[string][]UpdateInterceptor),
+		updateRetryPolicy:               linearUpdateRetryPolicy{},
+		sleepOnGenericError:             defaultSleepForOnGenericError,
+		pollBackoffByTaskName:           make(map[string]*PollBackoff),
+		pollBackoffStateByTaskName:      make(map[string]*pollBackoffState),
+		executionPoolSettingsByTaskName: make(map[string]executionPoolSettings),
+		healthByTaskName:                make(map[string]*taskHealth),
+		rateLimiterByTaskName:           make(map[string]*tokenBucket),
+		circuitBreakerByTaskName:        make(map[string]*circuitBreaker),
+		ctx:                             ctx,
+		cancel:                          cancel,
+		workerContextByTaskName:         make(map[string]*workerContext),
 	}
 }
 
@@ -98,7 +197,15 @@ func NewTaskRunnerWithApiClient(
 // Default is 200 millis, and this function can be used to increase/decrease the duration of the wait time
 // Useful to avoid excessive logs in the worker when there are intermittent issues
 func (c *TaskRunner) SetSleepOnGenericError(duration time.Duration) {
-	sleepForOnGenericError = duration
+	c.sleepOnGenericErrorMutex.Lock()
+	defer c.sleepOnGenericErrorMutex.Unlock()
+	c.sleepOnGenericError = duration
+}
+
+func (c *TaskRunner) getSleepOnGenericError() time.Duration {
+	c.sleepOnGenericErrorMutex.RLock()
+	defer c.sleepOnGenericErrorMutex.RUnlock()
+	return c.sleepOnGenericError
 }
 
 // StartWorkerWithDomain starts a polling worker on a new goroutine, which only polls for tasks using the provided
@@ -106,7 +213,7 @@ func (c *TaskRunner) SetSleepOnGenericError(duration time.Duration) {
 //
 //	StartWorkerWithDomain(taskName, executeFunction, batchSize, pollInterval, "")
 func (c *TaskRunner) StartWorkerWithDomain(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration, domain string) error {
-	return c.startWorker(taskName, executeFunction, batchSize, pollInterval, domain)
+	return c.startWorker(taskName, withoutContext(executeFunction), batchSize, pollInterval, domain)
 }
 
 // StartWorker starts a worker on a new goroutine, which polls conductor periodically for tasks matching the provided
@@ -116,9 +223,22 @@ func (c *TaskRunner) StartWorkerWithDomain(taskName string, executeFunction mode
 // pollInterval and increases the batch size for the task, which applies to all tasks shared by this TaskRunner with the
 // same taskName.
 func (c *TaskRunner) StartWorker(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration) error {
+	return c.startWorker(taskName, withoutContext(executeFunction), batchSize, pollInterval, "")
+}
+
+// StartWorkerWithContext behaves like StartWorker, but executeFunction receives a context.Context which is cancelled
+// when the task is shut down, or once the task's responseTimeoutSeconds (or the timeoutSeconds of its task definition)
+// elapses. When the deadline is hit, the task is reported as FAILED with the timeout as reason for incompletion.
+func (c *TaskRunner) StartWorkerWithContext(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, batchSize int, pollInterval time.Duration) error {
 	return c.startWorker(taskName, executeFunction, batchSize, pollInterval, "")
 }
 
+// StartWorkerWithDomainAndContext behaves like StartWorkerWithContext, but only polls for tasks using the provided
+// domain.
+func (c *TaskRunner) StartWorkerWithDomainAndContext(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, batchSize int, pollInterval time.Duration, domain string) error {
+	return c.startWorker(taskName, executeFunction, batchSize, pollInterval, domain)
+}
+
 // SetBatchSize can be used to set the batch size for all workers running the provided task.
 func (c *TaskRunner) SetBatchSize(taskName string, batchSize int) error {
 	if batchSize < 0 {
@@ -131,15 +251,12 @@ func (c *TaskRunner) SetBatchSize(taskName string, batchSize int) error {
 	defer c.batchSizeByTaskNameMutex.Unlock()
 	previous := c.batchSizeByTaskName[taskName]
 	c.batchSizeByTaskName[taskName] = batchSize
-	log.Debug(
-		"Set batchSize for task: ", taskName,
-		", from: ", previous,
-		", to: ", c.batchSizeByTaskName[taskName],
-	)
+	logger := c.taskLog(taskName)
+	logger.Debug("Set batchSize", "from", previous, "to", c.batchSizeByTaskName[taskName])
 	if batchSize == 0 {
-		log.Info("Stopped worker for task: ", taskName)
+		logger.Info("Stopped worker")
 	} else if previous == 0 && c.batchSizeByTaskName[taskName] > 0 {
-		log.Info("Started worker for task: ", taskName)
+		logger.Info("Started worker")
 	}
 	return nil
 }
@@ -156,13 +273,10 @@ func (c *TaskRunner) IncreaseBatchSize(taskName string, batchSize int) error {
 	defer c.batchSizeByTaskNameMutex.Unlock()
 	previous := c.batchSizeByTaskName[taskName]
 	c.batchSizeByTaskName[taskName] += batchSize
-	log.Debug(
-		"Increased batchSize for task: ", taskName,
-		", from: ", previous,
-		", to: ", c.batchSizeByTaskName[taskName],
-	)
+	logger := c.taskLog(taskName)
+	logger.Debug("Increased batchSize", "from", previous, "to", c.batchSizeByTaskName[taskName])
 	if previous == 0 {
-		log.Info("Started worker for task: ", taskName)
+		logger.Info("Started worker")
 	}
 	return nil
 }
@@ -179,14 +293,11 @@ func (c *TaskRunner) DecreaseBatchSize(taskName string, batchSize int) error {
 	defer c.batchSizeByTaskNameMutex.Unlock()
 	previous := c.batchSizeByTaskName[taskName]
 	c.batchSizeByTaskName[taskName] -= batchSize
-	log.Debug(
-		"Decreased batchSize for task: ", taskName,
-		", from: ", previous,
-		", to: ", c.batchSizeByTaskName[taskName],
-	)
+	logger := c.taskLog(taskName)
+	logger.Debug("Decreased batchSize", "from", previous, "to", c.batchSizeByTaskName[taskName])
 	if previous-batchSize <= 0 {
 		c.batchSizeByTaskName[taskName] = 0
-		log.Info("Stopped worker for task: ", taskName)
+		logger.Info("Stopped worker")
 	}
 	return nil
 }
@@ -211,13 +322,52 @@ func (c *TaskRunner) Resume(taskName string) {
 // Shutdown the TaskRunner will stop polling for tasks and once all running workers are done,
 // a signal will be sent to the WaitGroup to indicate that this worker has completed its work.
 // When used in conjunction with TaskRunner.WaitWorkers() it allows a graceful shutdown.
+// The context passed to running workers started with StartWorkerWithContext is cancelled.
 func (c *TaskRunner) Shutdown(taskName string) {
-	log.Info(
-		fmt.Sprintf(
-			"Shutting down workers for task: %s",
-			taskName,
-		),
-	)
+	c.taskLog(taskName).Info("Shutting down workers")
+	c.stopPolling(taskName)
+
+	c.workerContextByTaskNameMutex.Lock()
+	if workerCtx, ok := c.workerContextByTaskName[taskName]; ok {
+		workerCtx.cancel()
+		delete(c.workerContextByTaskName, taskName)
+	}
+	c.workerContextByTaskNameMutex.Unlock()
+}
+
+// ShutdownAll stops polling for every task and waits for the in-flight executions, and the update of their results,
+// to complete. If ctx is done before that, the context of the running workers is cancelled, pending result updates are
+// abandoned, and the number of executions still running by task name is returned along with the context's error.
+// Tasks returned by polls still in flight are handed back to Conductor, which offers them again, instead of being
+// executed.
+//
+// The TaskRunner can not be used to start new workers once ShutdownAll was called, StartWorker returns an error.
+func (c *TaskRunner) ShutdownAll(ctx context.Context) (map[string]int, error) {
+	log.Infow("Shutting down workers for all tasks")
+	for taskName := range c.GetBatchSizeForAll() {
+		c.stopPolling(taskName)
+	}
+	// flush the buffered updates now, instead of holding their workers until the batching window elapses
+	c.SetUpdateBatching(0, 0)
+	drained := make(chan struct{})
+	go func() {
+		c.workerWaitGroup.Wait()
+		close(drained)
+	}()
+	defer c.cancel()
+	select {
+	case <-drained:
+		log.Infow("All workers are done")
+		return map[string]int{}, nil
+	case <-ctx.Done():
+		runningWorkersByTaskName := c.getRunningWorkersForAll()
+		log.Warnw("Workers still running after shutdown deadline", "runningWorkers", runningWorkersByTaskName)
+		return runningWorkersByTaskName, ctx.Err()
+	}
+}
+
+// stopPolling unregisters the task, so that no more polls are made for it, without interrupting running workers.
+func (c *TaskRunner) stopPolling(taskName string) {
 	c.batchSizeByTaskNameMutex.Lock()
 	delete(c.batchSizeByTaskName, taskName)
 	c.batchSizeByTaskNameMutex.Unlock()
@@ -230,9 +380,28 @@ func (c *TaskRunner) Shutdown(taskName string) {
 	delete(c.pollIntervalByTaskName, taskName)
 	c.pollIntervalByTaskNameMutex.Unlock()
 
+	c.domainPollingMutex.Lock()
+	delete(c.domainPollingByTaskName, taskName)
+	c.domainPollingMutex.Unlock()
+
 	c.pollTimeoutMutex.Lock()
 	delete(c.pollTimeoutByTaskName, taskName)
 	c.pollTimeoutMutex.Unlock()
+
+	c.pollBackoffMutex.Lock()
+	delete(c.pollBackoffByTaskName, taskName)
+	delete(c.pollBackoffStateByTaskName, taskName)
+	c.pollBackoffMutex.Unlock()
+
+	c.healthMutex.Lock()
+	delete(c.healthByTaskName, taskName)
+	c.healthMutex.Unlock()
+
+	c.workerContextByTaskNameMutex.RLock()
+	if workerCtx, ok := c.workerContextByTaskName[taskName]; ok {
+		workerCtx.stopPolling()
+	}
+	c.workerContextByTaskNameMutex.RUnlock()
 }
 
 func (c *TaskRunner) isPaused(taskName string) bool {
@@ -247,7 +416,11 @@ func (c *TaskRunner) WaitWorkers() {
 	c.workerWaitGroup.Wait()
 }
 
-func (c *TaskRunner) startWorker(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration, taskDomain string) error {
+func (c *TaskRunner) startWorker(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, batchSize int, pollInterval time.Duration, taskDomain string) error {
+	if c.ctx.Err() != nil {
+		return fmt.Errorf("task runner was shut down, can not start worker for taskName: %s", taskName)
+	}
+	c.initWorkerContext(taskName)
 	c.SetPollIntervalForTask(taskName, pollInterval)
 	c.Resume(taskName)
 	previousMaxAllowedWorkers, err := c.getMaxAllowedWorkers(taskName)
@@ -258,80 +431,192 @@ func (c *TaskRunner) startWorker(taskName string, executeFunction model.ExecuteT
 	if err != nil {
 		return err
 	}
+	if previousMaxAllowedWorkers < 1 {
+		c.setDomainIfAbsent(taskName, taskDomain)
+	}
+	c.applyWorkerConfig(taskName, c.getTaskWorkerConfig(taskName))
 	if previousMaxAllowedWorkers < 1 {
 		c.workerWaitGroup.Add(1)
-		go c.work4ever(taskName, executeFunction, taskDomain)
-	}
-	log.Info(
-		fmt.Sprintf(
-			"Started %d worker(s) for taskName %s, polling in interval of %d ms",
-			batchSize,
-			taskName,
-			pollInterval.Milliseconds(),
-		),
-	)
+		go c.work4ever(taskName, executeFunction, c.startExecutionPool(taskName, executeFunction))
+	}
+	c.taskLog(taskName).Info("Started workers", "batchSize", batchSize, "pollIntervalMs", pollInterval.Milliseconds())
 	return nil
 }
 
-func (c *TaskRunner) work4ever(taskName string, executeFunction model.ExecuteTaskFunction, domain string) {
+func (c *TaskRunner) work4ever(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, pool *executionPool) {
 	defer c.workerWaitGroup.Done()
-	defer concurrency.HandlePanicError("poll_and_execute")
+	defer concurrency.HandleTaskPanicError(taskName, "poll_and_execute")
+	if pool != nil {
+		defer pool.close()
+	}
 	for c.isWorkerRegistered(taskName) {
-		c.workOnce(taskName, executeFunction, domain)
+		c.workOnce(taskName, executeFunction, pool)
 	}
 }
 
-func (c *TaskRunner) workOnce(taskName string, executeFunction model.ExecuteTaskFunction, domain string) {
+func (c *TaskRunner) workOnce(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, pool *executionPool) {
+	workerCtx := c.getWorkerContext(taskName)
+	if workerCtx.pollCtx.Err() != nil {
+		return
+	}
+	domain := formatDomains(c.GetDomainsForTask(taskName))
 	if c.isPaused(taskName) {
-		pauseOnGenericError(taskName, domain, fmt.Errorf("worker is paused"))
+		c.pauseOnGenericError(workerCtx.pollCtx, taskName, domain, fmt.Errorf("worker is paused"))
 		return
 	}
-	batchSize, err := c.getAvailableWorkerAmount(taskName)
+	batchSize, err := c.getAvailableWorkerAmount(taskName, pool)
 	if err != nil {
-		pauseOnGenericError(
-			taskName, domain,
+		c.pauseOnGenericError(
+			workerCtx.pollCtx, taskName, domain,
 			fmt.Errorf("failed to get the number of available workers, reason: %s", err.Error()),
 		)
 		return
 	}
 	if batchSize < 1 {
-		pauseOnNoAvailableWorkerError(taskName, domain)
+		if c.isExecutionPoolFull(taskName, pool) {
+			c.getMetrics().IncrementTaskExecutionQueueFull(taskName)
+		}
+		pauseOnNoAvailableWorkerError(workerCtx.pollCtx, taskName, domain)
+		return
+	}
+	circuitBreaker := c.getCircuitBreaker(taskName)
+	if circuitBreaker != nil {
+		maxTasks, wait := circuitBreaker.allowPoll()
+		if maxTasks == 0 && wait == 0 {
+			// the trial task is running, check again after the poll interval so that polling resumes soon after the
+			// circuit breaker closes
+			pollInterval, _ := c.GetPollIntervalForTask(taskName)
+			if pollInterval < sleepForOnNoAvailableWorker {
+				pollInterval = sleepForOnNoAvailableWorker
+			}
+			sleep(workerCtx.pollCtx, pollInterval)
+			return
+		}
+		if maxTasks == 0 {
+			c.getMetrics().IncrementTaskPaused(taskName)
+			sleep(workerCtx.pollCtx, wait)
+			return
+		}
+		if maxTasks > 0 && batchSize > maxTasks {
+			batchSize = maxTasks
+		}
+	}
+	rateLimiter := c.getRateLimiter(taskName)
+	if rateLimiter != nil {
+		batchSize = rateLimiter.take(batchSize)
+		if batchSize < 1 {
+			if circuitBreaker != nil {
+				circuitBreaker.releaseTrial("")
+			}
+			domainLog(taskName, domain).Trace("Rate limit reached")
+			sleep(workerCtx.pollCtx, rateLimiter.timeUntilNextToken())
+			return
+		}
+	}
+	tasks, err := c.pollDomains(taskName, batchSize)
+	// polls are not interrupted by shutdown, but the tasks they return are handed back to Conductor instead of being
+	// executed once the worker is shutting down
+	stopped := workerCtx.pollCtx.Err() != nil
+	if stopped && len(tasks) > 0 {
+		domainLog(taskName, domain).Info("Handing back tasks polled while shutting down", "count", len(tasks))
+		c.handBackTasks(taskName, tasks)
+		tasks = nil
+	}
+	if rateLimiter != nil {
+		rateLimiter.giveBack(batchSize - len(tasks))
+	}
+	if circuitBreaker != nil {
+		if len(tasks) == 0 {
+			circuitBreaker.releaseTrial("")
+		} else {
+			circuitBreaker.setTrialTask(tasks[0].TaskId)
+		}
+	}
+	if stopped {
 		return
 	}
-	tasks, err := c.batchPoll(taskName, batchSize, domain)
 	if err != nil {
-		pauseOnGenericError(
-			taskName, domain,
-			fmt.Errorf("failed to poll, reason: %s", err.Error()),
-		)
+		domainLog(taskName, domain).Error("Failed to poll", log.ErrorKey, err)
+		sleep(workerCtx.pollCtx, c.getIntervalAfterFailedPoll(taskName))
 		return
 	}
 	if len(tasks) < 1 {
 		pollInterval, err := c.GetPollIntervalForTask(taskName)
 		if err != nil {
-			log.Error(err)
-			pauseOnGenericError(
-				taskName, domain,
+			c.pauseOnGenericError(
+				workerCtx.pollCtx, taskName, domain,
 				fmt.Errorf("failed to get poll interval, reason: %s", err.Error()),
 			)
 			return
 		}
-		time.Sleep(pollInterval)
+		sleep(workerCtx.pollCtx, c.getIntervalAfterEmptyPoll(taskName, pollInterval))
 		return
 	}
+	c.resetPollBackoff(taskName)
 	for _, task := range tasks {
 		c.increaseRunningWorkers(taskName)
-		go c.executeAndUpdateTask(taskName, task, executeFunction)
+		if pool != nil {
+			pool.submit(workerCtx.ctx, task)
+		} else {
+			go c.executeAndUpdateTask(workerCtx.ctx, taskName, task, executeFunction)
+		}
+	}
+}
+
+// handBackTasks updates the tasks as IN_PROGRESS without callback delay, so that Conductor offers them again right
+// away rather than once their response timeout elapses.
+func (c *TaskRunner) handBackTasks(taskName string, tasks []model.Task) {
+	for i := range tasks {
+		taskResult := model.NewTaskResultFromTask(&tasks[i])
+		taskResult.Status = model.InProgressTask
+		taskResult.CallbackAfterSeconds = 0
+		taskResult.WorkerId = c.GetWorkerIdForTask(taskName)
+		if err := c.updateTaskWithRetry(taskName, taskResult); err != nil {
+			executionLog(&tasks[i]).Error("Failed to hand back task", log.ErrorKey, err)
+		}
 	}
 }
 
-func (c *TaskRunner) executeAndUpdateTask(taskName string, task model.Task, executeFunction model.ExecuteTaskFunction) {
+func (c *TaskRunner) executeAndUpdateTask(ctx context.Context, taskName string, task model.Task, executeFunction model.ExecuteTaskFunctionWithContext) {
 	defer c.runningWorkerDone(taskName)
-	defer concurrency.HandlePanicError("execute_and_update_task " + string(task.TaskId) + ": " + string(task.Status))
-	taskResult := c.executeTask(&task, executeFunction)
-	err := c.updateTaskWithRetry(taskName, taskResult)
+	defer concurrency.HandleTaskPanicError(taskName, "execute_and_update_task")
+	execute := chainExecuteInterceptors(
+		c.getExecuteInterceptors(taskName),
+		func(ctx context.Context, t *model.Task) *model.TaskResult {
+			return c.executeTask(ctx, taskName, t, executeFunction)
+		},
+	)
+	var taskResult *model.TaskResult
+	if circuitBreaker := c.getCircuitBreaker(taskName); circuitBreaker != nil {
+		// deferred so that the outcome is recorded whatever ends the execution
+		defer func() {
+			circuitBreaker.recordExecution(task.TaskId, taskResult)
+		}()
+	}
+	ctx = tracing.ExtractFromInput(ctx, task.InputData)
+	executeCtx, executeSpan := startTaskSpan(ctx, "execute "+taskName, &task)
+	taskResult = c.executeWithRecover(executeCtx, &task, execute)
+	if taskResult == nil {
+		executionLog(&task).Error("No result for task")
+		tracing.EndSpan(executeSpan, fmt.Errorf("no result for task"))
+		return
+	}
+	executeSpan.SetAttributes(attribute.String("conductor.task.status", string(taskResult.Status)))
+	if taskResult.Status == model.FailedTask || taskResult.Status == model.FailedWithTerminalErrorTask {
+		executeSpan.SetStatus(codes.Error, taskResult.ReasonForIncompletion)
+	}
+	executeSpan.End()
+	update := chainUpdateInterceptors(
+		c.getUpdateInterceptors(taskName),
+		func(ctx context.Context, t *model.Task, taskResult *model.TaskResult) error {
+			return c.updateTaskBatched(taskName, taskResult)
+		},
+	)
+	updateCtx, updateSpan := startTaskSpan(ctx, "update "+taskName, &task)
+	err := update(updateCtx, &task, taskResult)
+	tracing.EndSpan(updateSpan, err)
 	if err != nil {
-		log.Error("failed to update task ", taskName, ",taskId = ", task.TaskId, ",workflowId = ", task.WorkflowInstanceId, ",", err)
+		executionLog(&task).Error("Failed to update task", log.ErrorKey, err)
 	}
 }
 
@@ -344,12 +629,23 @@ func (c *TaskRunner) batchPoll(taskName string, count int, domain string) ([]mod
 	if domain != "" {
 		domainOptional = optional.NewString(domain)
 	}
-	log.Debug("Polling for task: ", taskName, ", in batches of size: ", count, ", with poll timeout: ", timeout)
-	metrics.IncrementTaskPoll(taskName)
+	logger := domainLog(taskName, domain)
+	logger.Debug("Polling for tasks", "count", count, "pollTimeout", timeout)
+	ctx, span := tracing.Tracer().Start(
+		c.ctx,
+		"poll "+taskName,
+		trace.WithAttributes(
+			attribute.String("conductor.task.type", taskName),
+			attribute.String("conductor.task.domain", domain),
+			attribute.Int("conductor.poll.count", count),
+		),
+	)
+	defer func() { tracing.EndSpan(span, err) }()
+	c.getMetrics().IncrementTaskPoll(taskName)
 	startTime := time.Now()
 	opts := &client.TaskResourceApiBatchPollOpts{
 		Domain:   domainOptional,
-		Workerid: optional.NewString(hostname),
+		Workerid: optional.NewString(c.GetWorkerIdForTask(taskName)),
 		Count:    optional.NewInt32(int32(count)),
 	}
 
@@ -358,17 +654,18 @@ func (c *TaskRunner) batchPoll(taskName string, count int, domain string) ([]mod
 	}
 
 	tasks, response, err := c.conductorTaskResourceClient.BatchPoll(
-		context.Background(),
+		ctx,
 		taskName,
 		opts,
 	)
 	spentTime := time.Since(startTime)
-	metrics.RecordTaskPollTime(
+	c.getMetrics().RecordTaskPollTime(
 		taskName,
 		spentTime.Seconds(),
 	)
+	c.recordPoll(taskName, err)
 	if err != nil {
-		metrics.IncrementTaskPollError(
+		c.getMetrics().IncrementTaskPollError(
 			taskName, err,
 		)
 		return nil, err
@@ -376,92 +673,276 @@ func (c *TaskRunner) batchPoll(taskName string, count int, domain string) ([]mod
 	if response.StatusCode == 204 {
 		return nil, nil
 	}
-	log.Debug(fmt.Sprintf("Polled %d tasks for taskName: %s", len(tasks), taskName))
+	logger.Debug("Polled tasks", "count", len(tasks))
+	span.SetAttributes(attribute.Int("conductor.poll.tasks", len(tasks)))
 	return tasks, nil
 }
 
-func (c *TaskRunner) executeTask(t *model.Task, executeFunction model.ExecuteTaskFunction) *model.TaskResult {
-	log.Trace(
-		"Executing task of type: ", t.TaskDefName,
-		", taskId: ", t.TaskId,
-		", workflowId: ", t.WorkflowInstanceId,
-	)
+func (c *TaskRunner) executeTask(ctx context.Context, taskName string, t *model.Task, executeFunction model.ExecuteTaskFunctionWithContext) *model.TaskResult {
+	executionLog(t).Trace("Executing task")
+	logger := newTaskLogger(t)
+	ctx = withTaskLogger(ctx, logger)
+	stopLogFlush := c.flushTaskLogsWhileRunning(taskName, logger, c.GetTaskLogFlushInterval())
+	timeout := getTaskExecutionTimeout(t, true)
+	stopLeaseExtension := func() {}
+	if t.ResponseTimeoutSeconds > 0 && c.IsLeaseExtensionEnabledForTask(taskName) {
+		lease := newTaskLease(t, logger)
+		ctx = withTaskLease(ctx, lease)
+		stopLeaseExtension = c.extendLeaseWhileRunning(taskName, lease, getLeaseExtensionInterval(t))
+		timeout = getTaskExecutionTimeout(t, false)
+	}
+	taskResult := c.executeTaskWithTimeout(ctx, t, executeFunction, timeout)
+	stopLeaseExtension()
+	stopLogFlush()
+	taskResult.Logs = append(logger.drain(), taskResult.Logs...)
+	taskResult.WorkerId = c.GetWorkerIdForTask(taskName)
+	return taskResult
+}
+
+func (c *TaskRunner) executeTaskWithTimeout(ctx context.Context, t *model.Task, executeFunction model.ExecuteTaskFunctionWithContext, timeout time.Duration) *model.TaskResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	startTime := time.Now()
-	taskExecutionOutput, err := executeFunction(t)
+	taskExecutionOutput, err := invokeExecuteFunction(ctx, t, executeFunction)
 	spentTime := time.Since(startTime)
-	metrics.RecordTaskExecuteTime(
+	c.getMetrics().RecordTaskExecuteTime(
 		t.TaskDefName, float64(spentTime.Milliseconds()),
 	)
+	var panicErr *workerPanicError
+	if errors.As(err, &panicErr) {
+		return c.newPanicTaskResult(t, panicErr)
+	}
+	// a worker ignoring its context may still succeed after the deadline, in which case its result is reported
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("task execution exceeded its timeout of %s", timeout)
+		c.getMetrics().IncrementTaskExecuteError(t.TaskDefName, err)
+		executionLog(t).Debug("Task execution timed out", "timeout", timeout)
+		return model.NewTaskResultFromTaskWithError(t, err)
+	}
 	if err != nil {
-		metrics.IncrementTaskExecuteError(t.TaskDefName, err)
-		log.Debug(
-			"failed to execute task",
-			", reason: ", err.Error(),
-			", taskName: ", t.TaskDefName,
-			", taskId: ", t.TaskId,
-			", workflowId: ", t.WorkflowInstanceId,
-		)
+		c.getMetrics().IncrementTaskExecuteError(t.TaskDefName, err)
+		executionLog(t).Debug("Failed to execute task", log.ErrorKey, err)
 		if taskExecutionOutput == nil {
 			return model.NewTaskResultFromTaskWithError(t, err)
 		}
 	}
 	taskResult, err := model.GetTaskResultFromTaskExecutionOutput(t, taskExecutionOutput)
 	if err != nil {
-		log.Debug(
-			"Failed to extract taskResult from generated object",
-			", reason: ", err.Error(),
-			", task type: ", t.TaskDefName,
-			", taskId: ", t.TaskId,
-			", workflowId: ", t.WorkflowInstanceId,
-			", response: ", err,
-		)
+		executionLog(t).Debug("Failed to extract taskResult from generated object", log.ErrorKey, err)
 		return model.NewTaskResultFromTaskWithError(t, err)
 	}
-	log.Trace(
-		"Executed task of type: ", t.TaskDefName,
-		", taskId: ", t.TaskId,
-		", workflowId: ", t.WorkflowInstanceId,
-	)
+	executionLog(t).Trace("Executed task")
 	return taskResult
 }
 
 func (c *TaskRunner) updateTaskWithRetry(taskName string, taskResult *model.TaskResult) error {
-	log.Debug(
-		"Updating task of type: ", taskName,
-		", taskId: ", taskResult.TaskId,
-		", workflowId: ", taskResult.WorkflowInstanceId,
-	)
-	var lastError error
-	for attempt := 0; attempt <= taskUpdateRetryAttemptsLimit; attempt += 1 {
-		if attempt > 0 {
-			// Wait for [10s, 20s, 30s] before next attempt
-			amount := attempt * 10
-			time.Sleep(time.Duration(amount) * time.Second)
-		}
+	logger := resultLog(taskName, taskResult)
+	logger.Debug("Updating task")
+	retryPolicy := c.getUpdateRetryPolicy()
+	startTime := time.Now()
+	for attempt := 1; ; attempt += 1 {
 		_, err := c.updateTask(taskName, taskResult)
 		if err == nil {
-			log.Debug(
-				"Updated task of type: ", taskName,
-				", taskId: ", taskResult.TaskId,
-				", workflowId: ", taskResult.WorkflowInstanceId,
-			)
+			logger.Debug("Updated task")
 			return nil
 		}
-		metrics.IncrementTaskUpdateError(taskName, err)
-		lastError = err
+		c.getMetrics().IncrementTaskUpdateError(taskName, err)
+		if c.ctx.Err() != nil {
+			return c.saveUndeliveredTaskResult(taskName, taskResult, fmt.Errorf("failed to update task %s, runner was shut down. %s", taskName, err))
+		}
+		if !retryPolicy.IsRetryable(err) {
+			return fmt.Errorf("failed to update task %s, the error is not retryable. %s", taskName, err)
+		}
+		backoff, ok := retryPolicy.Backoff(attempt, time.Since(startTime))
+		if !ok {
+			return c.saveUndeliveredTaskResult(taskName, taskResult, fmt.Errorf("failed to update task %s after %d attempts. %s", taskName, attempt, err))
+		}
+		if !sleep(c.ctx, backoff) {
+			return c.saveUndeliveredTaskResult(taskName, taskResult, fmt.Errorf("failed to update task %s, runner was shut down. %s", taskName, err))
+		}
+	}
+}
+
+// saveUndeliveredTaskResult keeps the result in the TaskResultStore, if any, for it to be replayed later on.
+func (c *TaskRunner) saveUndeliveredTaskResult(taskName string, taskResult *model.TaskResult, err error) error {
+	store := c.getTaskResultStore()
+	if store == nil {
+		return err
+	}
+	saveErr := store.Save(PendingTaskResult{TaskName: taskName, TaskResult: taskResult})
+	if saveErr != nil {
+		return fmt.Errorf("%s, and failed to save it for replay. %s", err, saveErr)
+	}
+	resultLog(taskName, taskResult).Warn("Saved undelivered task result for replay", log.ErrorKey, err)
+	return nil
+}
+
+// replayUndeliveredTaskResults periodically sends the results kept in the TaskResultStore, until the runner shuts
+// down. Each round stops at the first retryable failure, as the server is likely still unreachable.
+func (c *TaskRunner) replayUndeliveredTaskResults(ctx context.Context, store TaskResultStore, replayInterval time.Duration) {
+	defer concurrency.HandlePanicError("replay_task_results")
+	for sleep(ctx, replayInterval) {
+		pendingResults, err := store.List()
+		if err != nil {
+			log.Errorw("Failed to list task results to replay", log.ErrorKey, err)
+			continue
+		}
+		retryPolicy := c.getUpdateRetryPolicy()
+		for _, pending := range pendingResults {
+			logger := resultLog(pending.TaskName, pending.TaskResult)
+			_, err := c.updateTask(pending.TaskName, pending.TaskResult)
+			if err != nil && retryPolicy.IsRetryable(err) {
+				logger.Debug("Failed to replay task result", log.ErrorKey, err)
+				break
+			}
+			if err != nil {
+				logger.Error("Dropping task result, the error is not retryable", log.ErrorKey, err)
+			} else {
+				logger.Info("Replayed task result")
+			}
+			if err := store.Remove(pending.TaskResult.TaskId); err != nil {
+				logger.Error("Failed to remove replayed task result", log.ErrorKey, err)
+			}
+		}
 	}
-	return fmt.Errorf("failed to update task %s after %d attempts. %s", taskName, taskUpdateRetryAttemptsLimit, lastError)
 }
 
 func (c *TaskRunner) updateTask(taskName string, taskResult *model.TaskResult) (*http.Response, error) {
 	startTime := time.Now()
-	_, response, err := c.conductorTaskResourceClient.UpdateTask(context.Background(), taskResult)
+	_, response, err := c.conductorTaskResourceClient.UpdateTask(c.ctx, taskResult)
 	spentTime := time.Since(startTime).Milliseconds()
-	metrics.RecordTaskUpdateTime(taskName, float64(spentTime))
+	c.getMetrics().RecordTaskUpdateTime(taskName, float64(spentTime))
+	if err != nil {
+		c.recordUpdateError(taskName, err)
+	}
 	return response, err
 }
 
-func (c *TaskRunner) getAvailableWorkerAmount(taskName string) (int, error) {
+// getTaskExecutionTimeout returns the time left before the server considers the task timed out, which is the earliest
+// of its responseTimeoutSeconds, unless the lease of the task is extended, and the timeoutSeconds of its task
+// definition. Zero means there is no timeout.
+func getTaskExecutionTimeout(t *model.Task, includeResponseTimeout bool) time.Duration {
+	var timeout time.Duration
+	if includeResponseTimeout {
+		timeout = time.Duration(t.ResponseTimeoutSeconds) * time.Second
+	}
+	if t.TaskDefinition != nil && t.TaskDefinition.TimeoutSeconds > 0 && t.StartTime > 0 {
+		deadline := time.UnixMilli(t.StartTime).Add(time.Duration(t.TaskDefinition.TimeoutSeconds) * time.Second)
+		remaining := time.Until(deadline)
+		if remaining < time.Millisecond {
+			remaining = time.Millisecond
+		}
+		if timeout <= 0 || remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return timeout
+}
+
+// workerPanicError is returned by invokeExecuteFunction when the execute function panics.
+type workerPanicError struct {
+	value interface{}
+	stack []byte
+}
+
+func (e *workerPanicError) Error() string {
+	return fmt.Sprintf("worker panicked: %v", e.value)
+}
+
+func invokeExecuteFunction(ctx context.Context, t *model.Task, executeFunction model.ExecuteTaskFunctionWithContext) (output interface{}, err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			output = nil
+			err = &workerPanicError{
+				value: value,
+				stack: debug.Stack(),
+			}
+		}
+	}()
+	return executeFunction(ctx, t)
+}
+
+// executeWithRecover runs the execute chain of the task, turning a panic raised by an execute interceptor into a
+// failed result, so that the task is still updated.
+func (c *TaskRunner) executeWithRecover(ctx context.Context, t *model.Task, execute ExecuteHandler) (taskResult *model.TaskResult) {
+	defer func() {
+		if value := recover(); value != nil {
+			taskResult = c.newPanicTaskResult(t, &workerPanicError{
+				value: value,
+				stack: debug.Stack(),
+			})
+		}
+	}()
+	return execute(ctx, t)
+}
+
+// newPanicTaskResult reports the panic and returns the result of the task, with the stack added to its logs.
+func (c *TaskRunner) newPanicTaskResult(t *model.Task, panicErr *workerPanicError) *model.TaskResult {
+	c.getMetrics().IncrementTaskUncaughtException(t.TaskDefName)
+	executionLog(t).Error(
+		"Uncaught panic while executing task",
+		log.ErrorKey, panicErr.value,
+		"stack", string(panicErr.stack),
+	)
+	taskResult := model.NewTaskResultFromTaskWithError(t, panicErr)
+	taskResult.Status = c.GetPanicTaskResultStatus()
+	taskResult.Logs = append(taskResult.Logs, model.TaskExecLog{
+		Log:         string(panicErr.stack),
+		TaskId:      t.TaskId,
+		CreatedTime: time.Now().UnixMilli(),
+	})
+	return taskResult
+}
+
+func withoutContext(executeFunction model.ExecuteTaskFunction) model.ExecuteTaskFunctionWithContext {
+	return func(ctx context.Context, t *model.Task) (interface{}, error) {
+		return executeFunction(t)
+	}
+}
+
+func (c *TaskRunner) initWorkerContext(taskName string) {
+	c.workerContextByTaskNameMutex.Lock()
+	defer c.workerContextByTaskNameMutex.Unlock()
+	if _, ok := c.workerContextByTaskName[taskName]; ok {
+		return
+	}
+	c.workerContextByTaskName[taskName] = newWorkerContext(c.ctx)
+}
+
+func (c *TaskRunner) getWorkerContext(taskName string) *workerContext {
+	c.workerContextByTaskNameMutex.RLock()
+	defer c.workerContextByTaskNameMutex.RUnlock()
+	workerCtx, ok := c.workerContextByTaskName[taskName]
+	if !ok {
+		// the task was shut down, its workers must not get a context which is never cancelled
+		return newStoppedWorkerContext(c.ctx)
+	}
+	return workerCtx
+}
+
+// newStoppedWorkerContext returns a workerContext whose polling and executions are already cancelled.
+func newStoppedWorkerContext(parent context.Context) *workerContext {
+	workerCtx := newWorkerContext(parent)
+	workerCtx.stopPolling()
+	workerCtx.cancel()
+	return workerCtx
+}
+
+func newWorkerContext(parent context.Context) *workerContext {
+	pollCtx, stopPolling := context.WithCancel(parent)
+	ctx, cancel := context.WithCancel(parent)
+	return &workerContext{
+		pollCtx:     pollCtx,
+		stopPolling: stopPolling,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (c *TaskRunner) getAvailableWorkerAmount(taskName string, pool *executionPool) (int, error) {
 	allowed, err := c.getMaxAllowedWorkers(taskName)
 	if err != nil {
 		return -1, err
@@ -470,9 +951,26 @@ func (c *TaskRunner) getAvailableWorkerAmount(taskName string) (int, error) {
 	if err != nil {
 		return -1, err
 	}
+	if pool != nil {
+		available := pool.getAvailableSlots(running)
+		if allowed < available {
+			return allowed, nil
+		}
+		return available, nil
+	}
 	return allowed - running, nil
 }
 
+// isExecutionPoolFull returns whether the execution pool of the worker has no slot left, as opposed to the worker
+// being stopped with a batch size of 0.
+func (c *TaskRunner) isExecutionPoolFull(taskName string, pool *executionPool) bool {
+	if pool == nil {
+		return false
+	}
+	running, err := c.getRunningWorkers(taskName)
+	return err == nil && pool.getAvailableSlots(running) < 1
+}
+
 func (c *TaskRunner) getMaxAllowedWorkers(taskName string) (int, error) {
 	c.batchSizeByTaskNameMutex.RLock()
 	defer c.batchSizeByTaskNameMutex.RUnlock()
@@ -493,6 +991,18 @@ func (c *TaskRunner) getRunningWorkers(taskName string) (int, error) {
 	return amount, nil
 }
 
+func (c *TaskRunner) getRunningWorkersForAll() map[string]int {
+	c.runningWorkersByTaskNameMutex.RLock()
+	defer c.runningWorkersByTaskNameMutex.RUnlock()
+	runningWorkersByTaskName := make(map[string]int)
+	for taskName, amount := range c.runningWorkersByTaskName {
+		if amount > 0 {
+			runningWorkersByTaskName[taskName] = amount
+		}
+	}
+	return runningWorkersByTaskName
+}
+
 func (c *TaskRunner) isWorkerRegistered(taskName string) bool {
 	c.batchSizeByTaskNameMutex.RLock()
 	defer c.batchSizeByTaskNameMutex.RUnlock()
@@ -505,7 +1015,7 @@ func (c *TaskRunner) increaseRunningWorkers(taskName string) error {
 	defer c.runningWorkersByTaskNameMutex.Unlock()
 	c.runningWorkersByTaskName[taskName] += 1
 	c.workerWaitGroup.Add(1)
-	log.Trace("Increased running workers for task: ", taskName)
+	c.taskLog(taskName).Trace("Increased running workers")
 	return nil
 }
 
@@ -514,7 +1024,7 @@ func (c *TaskRunner) runningWorkerDone(taskName string) error {
 	defer c.runningWorkersByTaskNameMutex.Unlock()
 	c.runningWorkersByTaskName[taskName] -= 1
 	c.workerWaitGroup.Done()
-	log.Trace("Running worker done for task: ", taskName)
+	c.taskLog(taskName).Trace("Running worker done")
 	return nil
 }
 
@@ -522,7 +1032,7 @@ func (c *TaskRunner) increaseMaxAllowedWorkers(taskName string, batchSize int) e
 	c.batchSizeByTaskNameMutex.Lock()
 	defer c.batchSizeByTaskNameMutex.Unlock()
 	c.batchSizeByTaskName[taskName] += batchSize
-	log.Debug("Increased max allowed workers of task: ", taskName, ", by: ", batchSize)
+	c.taskLog(taskName).Debug("Increased max allowed workers", "by", batchSize)
 	return nil
 }
 
@@ -531,7 +1041,7 @@ func (c *TaskRunner) SetPollIntervalForTask(taskName string, pollInterval time.D
 	c.pollIntervalByTaskNameMutex.Lock()
 	defer c.pollIntervalByTaskNameMutex.Unlock()
 	c.pollIntervalByTaskName[taskName] = pollInterval
-	log.Info("Updated poll interval for task: ", taskName, " to: ", pollInterval.Milliseconds(), "ms")
+	c.taskLog(taskName).Info("Updated poll interval", "pollIntervalMs", pollInterval.Milliseconds())
 	return nil
 }
 
@@ -570,14 +1080,100 @@ func (c *TaskRunner) GetBatchSizeForTask(taskName string) (batchSize int) {
 	return batchSize
 }
 
-func pauseOnGenericError(taskName string, domain string, err error) {
-	log.Error(fmt.Errorf("[%s][%s] %s", taskName, domain, err))
-	time.Sleep(sleepForOnGenericError)
+func (c *TaskRunner) pauseOnGenericError(ctx context.Context, taskName string, domain string, err error) {
+	domainLog(taskName, domain).Error(err.Error())
+	sleep(ctx, c.getSleepOnGenericError())
 }
 
-func pauseOnNoAvailableWorkerError(taskName string, domain string) {
-	log.Trace(fmt.Errorf("no worker available for the task %s, domain %s", taskName, domain))
-	time.Sleep(sleepForOnNoAvailableWorker)
+func pauseOnNoAvailableWorkerError(ctx context.Context, taskName string, domain string) {
+	domainLog(taskName, domain).Trace("No worker available")
+	sleep(ctx, sleepForOnNoAvailableWorker)
+}
+
+// sleep waits for the given duration, returning false if ctx is done first.
+func sleep(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// SetPanicTaskResultStatus sets the status reported for tasks whose execute function panics, which is either
+// model.FailedTask (the default, so that the task is retried) or model.FailedWithTerminalErrorTask.
+func (c *TaskRunner) SetPanicTaskResultStatus(status model.TaskResultStatus) error {
+	if status != model.FailedTask && status != model.FailedWithTerminalErrorTask {
+		return fmt.Errorf("invalid status for panicking tasks: %s", status)
+	}
+	c.panicTaskResultStatusMutex.Lock()
+	defer c.panicTaskResultStatusMutex.Unlock()
+	c.panicTaskResultStatus = status
+	return nil
+}
+
+// GetPanicTaskResultStatus returns the status reported for tasks whose execute function panics.
+func (c *TaskRunner) GetPanicTaskResultStatus() model.TaskResultStatus {
+	c.panicTaskResultStatusMutex.RLock()
+	defer c.panicTaskResultStatusMutex.RUnlock()
+	return c.panicTaskResultStatus
+}
+
+// SetUpdateRetryPolicy sets the policy used to retry failed task updates. By default, failed updates are retried up to 3
+// times, waiting 10s, 20s and 30s between attempts.
+func (c *TaskRunner) SetUpdateRetryPolicy(retryPolicy UpdateRetryPolicy) {
+	c.updateRetryPolicyMutex.Lock()
+	defer c.updateRetryPolicyMutex.Unlock()
+	c.updateRetryPolicy = retryPolicy
+}
+
+func (c *TaskRunner) getUpdateRetryPolicy() UpdateRetryPolicy {
+	c.updateRetryPolicyMutex.RLock()
+	defer c.updateRetryPolicyMutex.RUnlock()
+	return c.updateRetryPolicy
+}
+
+// SetTaskResultStore sets the store which keeps the task results that could not be delivered once the update retry
+// policy gives up, or because the runner shut down. Every replayInterval, the stored results are sent to Conductor again
+// and removed from the store once delivered.
+func (c *TaskRunner) SetTaskResultStore(store TaskResultStore, replayInterval time.Duration) {
+	c.taskResultStoreMutex.Lock()
+	defer c.taskResultStoreMutex.Unlock()
+	if c.stopReplay != nil {
+		c.stopReplay()
+		c.stopReplay = nil
+	}
+	c.taskResultStore = store
+	if store != nil {
+		var ctx context.Context
+		ctx, c.stopReplay = context.WithCancel(c.ctx)
+		go c.replayUndeliveredTaskResults(ctx, store, replayInterval)
+	}
+}
+
+func (c *TaskRunner) getTaskResultStore() TaskResultStore {
+	c.taskResultStoreMutex.RLock()
+	defer c.taskResultStoreMutex.RUnlock()
+	return c.taskResultStore
+}
+
+// SetMetricsRecorder sets the recorder of the metrics of the workers, instead of the one set with
+// metrics.SetRecorder, so that the metrics of several TaskRunner can be kept apart. Nil restores the default.
+func (c *TaskRunner) SetMetricsRecorder(recorder metrics.Recorder) {
+	c.metricsMutex.Lock()
+	defer c.metricsMutex.Unlock()
+	c.metrics = metrics.NewMetrics(recorder)
+}
+
+func (c *TaskRunner) getMetrics() *metrics.Metrics {
+	c.metricsMutex.RLock()
+	defer c.metricsMutex.RUnlock()
+	if c.metrics == nil {
+		return metrics.Default()
+	}
+	return c.metrics
 }
 
 // SetPollTimeout sets the default poll timeout for all tasks. If not explicitly set,
@@ -586,7 +1182,7 @@ func (c *TaskRunner) SetPollTimeout(pollTimeout time.Duration) error {
 	c.pollTimeoutMutex.Lock()
 	defer c.pollTimeoutMutex.Unlock()
 	c.pollTimeout = pollTimeout
-	log.Info("Updated poll timeout to: ", pollTimeout.Milliseconds(), "ms")
+	log.Infow("Updated poll timeout", "pollTimeoutMs", pollTimeout.Milliseconds())
 	return nil
 }
 
@@ -617,6 +1213,6 @@ func (c *TaskRunner) SetPollTimeoutForTask(taskName string, pollTimeout time.Dur
 	c.pollTimeoutMutex.Lock()
 	defer c.pollTimeoutMutex.Unlock()
 	c.pollTimeoutByTaskName[taskName] = pollTimeout
-	log.Info("Updated poll timeout for task: ", taskName, " to: ", pollTimeout.Milliseconds(), "ms")
+	c.taskLog(taskName).Info("Updated poll timeout", "pollTimeoutMs", pollTimeout.Milliseconds())
 	return nil
 }
@@ -0,0 +1,35 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startTaskSpan starts a span about the provided task. The execution of a task is traced as part of the workflow which
+// scheduled it when the trace context was passed on in the task input.
+func startTaskSpan(ctx context.Context, spanName string, t *model.Task) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(
+		ctx,
+		spanName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("conductor.task.type", t.TaskDefName),
+			attribute.String("conductor.task.id", t.TaskId),
+			attribute.String("conductor.workflow.id", t.WorkflowInstanceId),
+			attribute.String("conductor.task.domain", t.Domain),
+		),
+	)
+}
@@ -0,0 +1,62 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// TypedWorker is a worker which receives the input of the task decoded into In, and whose Out is encoded as the
+// output of the task, the same way as the output of a model.ExecuteTaskFunction. Out can be a *model.TaskResult for
+// finer control over the result.
+type TypedWorker[In any, Out any] func(ctx context.Context, input In) (Out, error)
+
+// StartTypedWorker starts a TypedWorker on the provided TaskRunner. Equivalent to StartWorkerWithContext, with the
+// input of the task decoded into In. Tasks whose input can not be decoded are failed with FAILED_WITH_TERMINAL_ERROR.
+func StartTypedWorker[In any, Out any](taskRunner *TaskRunner, taskName string, worker TypedWorker[In, Out], batchSize int, pollInterval time.Duration) error {
+	return taskRunner.StartWorkerWithContext(taskName, worker.ToExecuteTaskFunction(), batchSize, pollInterval)
+}
+
+// StartTypedWorkerWithDomain behaves like StartTypedWorker, but only polls for tasks using the provided domain.
+func StartTypedWorkerWithDomain[In any, Out any](taskRunner *TaskRunner, taskName string, worker TypedWorker[In, Out], batchSize int, pollInterval time.Duration, domain string) error {
+	return taskRunner.StartWorkerWithDomainAndContext(taskName, worker.ToExecuteTaskFunction(), batchSize, pollInterval, domain)
+}
+
+// ToExecuteTaskFunction adapts the TypedWorker to a model.ExecuteTaskFunctionWithContext.
+func (w TypedWorker[In, Out]) ToExecuteTaskFunction() model.ExecuteTaskFunctionWithContext {
+	return func(ctx context.Context, t *model.Task) (interface{}, error) {
+		input, err := decodeTaskInput[In](t)
+		if err != nil {
+			return nil, model.NewNonRetryableError(err)
+		}
+		output, err := w(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+}
+
+func decodeTaskInput[In any](t *model.Task) (In, error) {
+	var input In
+	data, err := json.Marshal(t.InputData)
+	if err == nil {
+		err = json.Unmarshal(data, &input)
+	}
+	if err != nil {
+		return input, fmt.Errorf("failed to decode input of task %s into %T, reason: %s", t.TaskDefName, input, err.Error())
+	}
+	return input, nil
+}
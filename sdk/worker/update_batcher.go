@@ -0,0 +1,234 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// pendingUpdate is a task result waiting in an updateBatcher, along with the channel its outcome is sent to.
+type pendingUpdate struct {
+	taskName   string
+	taskResult *model.TaskResult
+	done       chan error
+	// claimed is set by whichever of the batcher or the worker sends the update, so that it is sent only once.
+	claimed *atomic.Bool
+}
+
+// claim returns whether the caller is the one sending the update.
+func (u pendingUpdate) claim() bool {
+	return u.claimed.CompareAndSwap(false, true)
+}
+
+// updateBatcher coalesces task results over a window and sends them to the server in a single request.
+type updateBatcher struct {
+	runner       *TaskRunner
+	window       time.Duration
+	maxBatchSize int
+	updates      chan pendingUpdate
+	ctx          context.Context
+	// stopped is closed once the batcher stopped taking updates, after flushing the ones it took.
+	stopped chan struct{}
+	// probed is set once the server answered the probe of the batch update endpoint, and unsupported once it rejected
+	// it or a batch update for lack of endpoint. Both are only accessed by run.
+	probed      bool
+	unsupported bool
+}
+
+// SetUpdateBatching makes the TaskRunner send task results in batches of up to maxBatchSize, sent once window elapses
+// after the first result of the batch. Each result still waits for its batch to be sent before its worker is done.
+// Should the batch fail, or should the server not support batch updates, every result of the batch is updated on its
+// own, with the retries of the UpdateRetryPolicy. A zero window disables batching, which is the default.
+//
+// Batches are sent to POST /tasks/batch, which Conductor OSS servers do not provide. Before the first batch, the
+// endpoint is probed with an empty batch, and results are only sent in batches if the server accepts it.
+func (c *TaskRunner) SetUpdateBatching(window time.Duration, maxBatchSize int) error {
+	if window > 0 && maxBatchSize < 1 {
+		return fmt.Errorf("maxBatchSize must be positive")
+	}
+	c.updateBatcherMutex.Lock()
+	defer c.updateBatcherMutex.Unlock()
+	if c.stopUpdateBatcher != nil {
+		c.stopUpdateBatcher()
+		c.stopUpdateBatcher = nil
+		c.updateBatcher = nil
+	}
+	if window <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.updateBatcher = &updateBatcher{
+		runner:       c,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+		updates:      make(chan pendingUpdate, maxBatchSize),
+		ctx:          ctx,
+		stopped:      make(chan struct{}),
+	}
+	c.stopUpdateBatcher = cancel
+	go c.updateBatcher.run()
+	return nil
+}
+
+func (c *TaskRunner) getUpdateBatcher() *updateBatcher {
+	c.updateBatcherMutex.RLock()
+	defer c.updateBatcherMutex.RUnlock()
+	return c.updateBatcher
+}
+
+// updateTaskBatched updates the task through the update batcher if batching is enabled, or on its own otherwise, or
+// once the batcher is stopped.
+func (c *TaskRunner) updateTaskBatched(taskName string, taskResult *model.TaskResult) error {
+	batcher := c.getUpdateBatcher()
+	if batcher == nil || batcher.ctx.Err() != nil {
+		return c.updateTaskWithRetry(taskName, taskResult)
+	}
+	update := pendingUpdate{
+		taskName:   taskName,
+		taskResult: taskResult,
+		done:       make(chan error, 1),
+		claimed:    &atomic.Bool{},
+	}
+	select {
+	case batcher.updates <- update:
+	case <-batcher.ctx.Done():
+		return c.updateTaskWithRetry(taskName, taskResult)
+	}
+	select {
+	case err := <-update.done:
+		return err
+	case <-batcher.stopped:
+		// the update may have been queued after the batcher flushed its queue for the last time
+		if update.claim() {
+			return c.updateTaskWithRetry(taskName, taskResult)
+		}
+		return <-update.done
+	}
+}
+
+func (b *updateBatcher) run() {
+	defer concurrency.HandlePanicError("update_batcher")
+	defer close(b.stopped)
+	for {
+		var batch []pendingUpdate
+		select {
+		case update := <-b.updates:
+			batch = append(batch, update)
+		case <-b.ctx.Done():
+			b.flushQueue()
+			return
+		}
+		timer := time.NewTimer(b.window)
+	collect:
+		for len(batch) < b.maxBatchSize {
+			select {
+			case update := <-b.updates:
+				batch = append(batch, update)
+			case <-timer.C:
+				break collect
+			case <-b.ctx.Done():
+				break collect
+			}
+		}
+		timer.Stop()
+		b.flush(batch)
+	}
+}
+
+// flushQueue flushes the updates left in the queue once the batcher is stopped.
+func (b *updateBatcher) flushQueue() {
+	var batch []pendingUpdate
+	for {
+		select {
+		case update := <-b.updates:
+			batch = append(batch, update)
+		default:
+			b.flush(batch)
+			return
+		}
+	}
+}
+
+// flush sends the batch to the server, or falls back to updating each result on its own.
+func (b *updateBatcher) flush(batch []pendingUpdate) {
+	claimed := batch[:0]
+	for _, update := range batch {
+		if update.claim() {
+			claimed = append(claimed, update)
+		}
+	}
+	batch = claimed
+	if len(batch) > 1 && !b.probed {
+		b.probe()
+	}
+	if len(batch) > 1 && b.probed && !b.unsupported {
+		err := b.updateTasks(batch)
+		if err == nil {
+			for _, update := range batch {
+				update.done <- nil
+			}
+			return
+		}
+		log.Warnw("Failed to update a batch of tasks, updating them one by one", "count", len(batch), log.ErrorKey, err)
+	}
+	for _, update := range batch {
+		go func(update pendingUpdate) {
+			defer concurrency.HandleTaskPanicError(update.taskName, "update_batcher_fallback")
+			update.done <- b.runner.updateTaskWithRetry(update.taskName, update.taskResult)
+		}(update)
+	}
+}
+
+// probe sends an empty batch to the server to find out whether it supports batch updates. Any error response means
+// it does not, while the probe is sent again with the next batch if the server could not be reached.
+func (b *updateBatcher) probe() {
+	response, err := b.runner.conductorTaskResourceClient.UpdateTasks(b.runner.ctx, []*model.TaskResult{})
+	if err != nil && response == nil {
+		log.Warnw("Failed to check whether the server supports batch task updates", log.ErrorKey, err)
+		return
+	}
+	b.probed = true
+	if err != nil {
+		log.Infow("Batch task updates are not supported by the server, updating tasks one by one", log.ErrorKey, err)
+		b.unsupported = true
+	}
+}
+
+func (b *updateBatcher) updateTasks(batch []pendingUpdate) error {
+	taskResults := make([]*model.TaskResult, len(batch))
+	for i, update := range batch {
+		taskResults[i] = update.taskResult
+	}
+	startTime := time.Now()
+	response, err := b.runner.conductorTaskResourceClient.UpdateTasks(b.runner.ctx, taskResults)
+	spentTime := time.Since(startTime).Milliseconds()
+	for _, update := range batch {
+		b.runner.getMetrics().RecordTaskUpdateTime(update.taskName, float64(spentTime))
+	}
+	if err != nil && response != nil && isBatchUpdateUnsupported(response.StatusCode) {
+		log.Infow("Batch task updates are not supported by the server, updating tasks one by one")
+		b.unsupported = true
+	}
+	return err
+}
+
+func isBatchUpdateUnsupported(statusCode int) bool {
+	return statusCode == http.StatusNotFound ||
+		statusCode == http.StatusMethodNotAllowed ||
+		statusCode == http.StatusNotImplemented
+}
@@ -0,0 +1,114 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+)
+
+const taskUpdateRetryAttemptsLimit = 3
+
+// UpdateRetryPolicy decides whether a failed task update is retried, and how long to wait before doing so.
+type UpdateRetryPolicy interface {
+	// IsRetryable returns whether the update failing with err can succeed when retried.
+	IsRetryable(err error) bool
+	// Backoff returns the time to wait before the given retry attempt, starting at 1, given the time elapsed since the
+	// first attempt. No more retries are made once it returns false.
+	Backoff(attempt int, elapsed time.Duration) (time.Duration, bool)
+}
+
+// linearUpdateRetryPolicy retries every failed update up to 3 times, waiting 10s, 20s and 30s between attempts.
+type linearUpdateRetryPolicy struct{}
+
+func (p linearUpdateRetryPolicy) IsRetryable(err error) bool {
+	return true
+}
+
+func (p linearUpdateRetryPolicy) Backoff(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if attempt > taskUpdateRetryAttemptsLimit {
+		return 0, false
+	}
+	return time.Duration(attempt*10) * time.Second, true
+}
+
+// ExponentialBackoffRetryPolicy retries failed updates with an exponentially growing, randomized backoff.
+type ExponentialBackoffRetryPolicy struct {
+	// InitialInterval is the backoff before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff between two attempts.
+	MaxInterval time.Duration
+	// Multiplier is the factor by which the backoff grows after each attempt.
+	Multiplier float64
+	// RandomizationFactor spreads each backoff randomly over [backoff*(1-factor), backoff*(1+factor)].
+	RandomizationFactor float64
+	// MaxAttempts is the maximum number of retries. Zero means no limit.
+	MaxAttempts int
+	// MaxElapsedTime is the time after the first attempt past which no more retries are made. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// NonRetryableStatusCodes are the HTTP status codes for which updates are not retried.
+	NonRetryableStatusCodes []int
+}
+
+// NewExponentialBackoffRetryPolicy returns an ExponentialBackoffRetryPolicy starting at 500ms, growing by 1.5 up to
+// 1 minute with a randomization factor of 0.5, and giving up after 5 minutes. Updates rejected with one of 400, 404,
+// 409, 413 or 422 are not retried.
+func NewExponentialBackoffRetryPolicy() *ExponentialBackoffRetryPolicy {
+	return &ExponentialBackoffRetryPolicy{
+		InitialInterval:     500 * time.Millisecond,
+		MaxInterval:         time.Minute,
+		Multiplier:          1.5,
+		RandomizationFactor: 0.5,
+		MaxElapsedTime:      5 * time.Minute,
+		NonRetryableStatusCodes: []int{
+			http.StatusBadRequest,
+			http.StatusNotFound,
+			http.StatusConflict,
+			http.StatusRequestEntityTooLarge,
+			http.StatusUnprocessableEntity,
+		},
+	}
+}
+
+func (p *ExponentialBackoffRetryPolicy) IsRetryable(err error) bool {
+	var swaggerErr client.GenericSwaggerError
+	if !errors.As(err, &swaggerErr) {
+		return true
+	}
+	for _, statusCode := range p.NonRetryableStatusCodes {
+		if swaggerErr.StatusCode() == statusCode {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ExponentialBackoffRetryPolicy) Backoff(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return 0, false
+	}
+	backoff := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxInterval > 0 && backoff > float64(p.MaxInterval) {
+		backoff = float64(p.MaxInterval)
+	}
+	if p.RandomizationFactor > 0 {
+		delta := p.RandomizationFactor * backoff
+		backoff = backoff - delta + rand.Float64()*2*delta
+	}
+	if p.MaxElapsedTime > 0 && elapsed+time.Duration(backoff) > p.MaxElapsedTime {
+		return 0, false
+	}
+	return time.Duration(backoff), true
+}
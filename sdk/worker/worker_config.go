@@ -0,0 +1,293 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"gopkg.in/yaml.v3"
+)
+
+const workerConfigEnvPrefix = "CONDUCTOR_WORKER_"
+
+// workerConfigFile is the content of a worker config file, holding the settings of each task by task name.
+type workerConfigFile struct {
+	Workers map[string]taskWorkerConfig `json:"workers" yaml:"workers"`
+}
+
+// taskWorkerConfig holds the settings of a task which can be tuned without code changes. Unset settings keep their
+// current value. Durations are either a number of milliseconds or a Go duration such as "1.5s".
+type taskWorkerConfig struct {
+	PollInterval *string `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"`
+	PollTimeout  *string `json:"pollTimeout,omitempty" yaml:"pollTimeout,omitempty"`
+	ThreadCount  *int    `json:"threadCount,omitempty" yaml:"threadCount,omitempty"`
+	Domain       *string `json:"domain,omitempty" yaml:"domain,omitempty"`
+	Paused       *bool   `json:"paused,omitempty" yaml:"paused,omitempty"`
+}
+
+// override returns the config with the settings set in other replacing its own.
+func (t taskWorkerConfig) override(other taskWorkerConfig) taskWorkerConfig {
+	if other.PollInterval != nil {
+		t.PollInterval = other.PollInterval
+	}
+	if other.PollTimeout != nil {
+		t.PollTimeout = other.PollTimeout
+	}
+	if other.ThreadCount != nil {
+		t.ThreadCount = other.ThreadCount
+	}
+	if other.Domain != nil {
+		t.Domain = other.Domain
+	}
+	if other.Paused != nil {
+		t.Paused = other.Paused
+	}
+	return t
+}
+
+// changedSince returns the settings which differ from the ones of the previous config. Settings removed since the
+// previous config are left unset, so that they keep their current value.
+func (t taskWorkerConfig) changedSince(previous taskWorkerConfig) taskWorkerConfig {
+	changed := taskWorkerConfig{}
+	if t.PollInterval != nil && (previous.PollInterval == nil || *t.PollInterval != *previous.PollInterval) {
+		changed.PollInterval = t.PollInterval
+	}
+	if t.PollTimeout != nil && (previous.PollTimeout == nil || *t.PollTimeout != *previous.PollTimeout) {
+		changed.PollTimeout = t.PollTimeout
+	}
+	if t.ThreadCount != nil && (previous.ThreadCount == nil || *t.ThreadCount != *previous.ThreadCount) {
+		changed.ThreadCount = t.ThreadCount
+	}
+	if t.Domain != nil && (previous.Domain == nil || *t.Domain != *previous.Domain) {
+		changed.Domain = t.Domain
+	}
+	if t.Paused != nil && (previous.Paused == nil || *t.Paused != *previous.Paused) {
+		changed.Paused = t.Paused
+	}
+	return changed
+}
+
+// SetWorkerConfigFile loads the settings of workers from a YAML, or JSON if its extension is .json, file such as:
+//
+//	workers:
+//	  simple_task:
+//	    pollInterval: 100ms
+//	    pollTimeout: 50ms
+//	    threadCount: 5
+//	    domain: blue
+//	    paused: false
+//
+// The settings are applied to running workers and to workers started afterwards, overriding the settings passed when
+// starting them. With a positive reloadInterval, the file is checked for changes at that interval and reloaded, in
+// which case settings removed from the file keep their last value. On reloads, only the settings which changed in the
+// file are applied, so that runtime changes to the other settings, such as SetBatchSize, Pause or
+// SetWeightedDomainsForTask, are kept.
+//
+// Settings are also read from environment variables, which take precedence over the file and are applied when workers
+// start, named after the task name in upper case with characters other than letters and digits replaced by
+// underscores, such as CONDUCTOR_WORKER_SIMPLE_TASK_POLL_INTERVAL, _POLL_TIMEOUT, _THREAD_COUNT, _DOMAIN and _PAUSED.
+func (c *TaskRunner) SetWorkerConfigFile(path string, reloadInterval time.Duration) error {
+	config, modTime, err := loadWorkerConfigFile(path)
+	if err != nil {
+		return err
+	}
+	c.workerConfigMutex.Lock()
+	c.workerConfig = config.Workers
+	if c.stopWorkerConfigReload != nil {
+		c.stopWorkerConfigReload()
+		c.stopWorkerConfigReload = nil
+	}
+	if reloadInterval > 0 {
+		ctx, cancel := context.WithCancel(c.ctx)
+		c.stopWorkerConfigReload = cancel
+		go c.reloadWorkerConfigFile(ctx, path, modTime, reloadInterval)
+	}
+	c.workerConfigMutex.Unlock()
+	c.applyWorkerConfigForAll(nil)
+	return nil
+}
+
+func loadWorkerConfigFile(path string) (*workerConfigFile, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read worker config file %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read worker config file %s: %w", path, err)
+	}
+	config := &workerConfigFile{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, config)
+	} else {
+		err = yaml.Unmarshal(data, config)
+	}
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse worker config file %s: %w", path, err)
+	}
+	return config, info.ModTime(), nil
+}
+
+func (c *TaskRunner) reloadWorkerConfigFile(ctx context.Context, path string, modTime time.Time, interval time.Duration) {
+	defer concurrency.HandlePanicError("reload_worker_config")
+	for sleep(ctx, interval) {
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Warnw("Failed to check worker config file", "path", path, log.ErrorKey, err)
+			continue
+		}
+		if info.ModTime().Equal(modTime) {
+			continue
+		}
+		config, newModTime, err := loadWorkerConfigFile(path)
+		if err != nil {
+			log.Errorw("Failed to reload worker config", "path", path, log.ErrorKey, err)
+			continue
+		}
+		modTime = newModTime
+		log.Infow("Reloading worker config", "path", path)
+		c.workerConfigMutex.Lock()
+		previous := c.workerConfig
+		c.workerConfig = config.Workers
+		c.workerConfigMutex.Unlock()
+		c.applyWorkerConfigForAll(previous)
+	}
+}
+
+// applyWorkerConfigForAll applies the settings of the config file to the running workers. previous is the config
+// replaced by a reload, nil otherwise, in which case only the settings which changed since the previous config are
+// applied.
+func (c *TaskRunner) applyWorkerConfigForAll(previous map[string]taskWorkerConfig) {
+	c.batchSizeByTaskNameMutex.RLock()
+	taskNames := make([]string, 0, len(c.batchSizeByTaskName))
+	for taskName := range c.batchSizeByTaskName {
+		taskNames = append(taskNames, taskName)
+	}
+	c.batchSizeByTaskNameMutex.RUnlock()
+	c.workerConfigMutex.RLock()
+	current := c.workerConfig
+	c.workerConfigMutex.RUnlock()
+	for _, taskName := range taskNames {
+		env := getTaskWorkerConfigFromEnv(taskName)
+		config := current[taskName].override(env)
+		if previous != nil {
+			config = config.changedSince(previous[taskName].override(env))
+		}
+		c.applyWorkerConfig(taskName, config)
+	}
+}
+
+// getTaskWorkerConfig returns the settings of the task from the config file and environment variables.
+func (c *TaskRunner) getTaskWorkerConfig(taskName string) taskWorkerConfig {
+	c.workerConfigMutex.RLock()
+	config := c.workerConfig[taskName]
+	c.workerConfigMutex.RUnlock()
+	return config.override(getTaskWorkerConfigFromEnv(taskName))
+}
+
+// applyWorkerConfig applies the settings set in config to the workers of the task. Invalid settings are logged and
+// ignored.
+func (c *TaskRunner) applyWorkerConfig(taskName string, config taskWorkerConfig) {
+	if config.PollInterval != nil {
+		pollInterval, err := parseWorkerConfigDuration(*config.PollInterval)
+		if err != nil {
+			log.Errorw("Invalid poll interval", log.TaskTypeKey, taskName, log.ErrorKey, err)
+		} else {
+			c.SetPollIntervalForTask(taskName, pollInterval)
+		}
+	}
+	if config.PollTimeout != nil {
+		pollTimeout, err := parseWorkerConfigDuration(*config.PollTimeout)
+		if err != nil {
+			log.Errorw("Invalid poll timeout", log.TaskTypeKey, taskName, log.ErrorKey, err)
+		} else {
+			c.SetPollTimeoutForTask(taskName, pollTimeout)
+		}
+	}
+	if config.ThreadCount != nil {
+		err := c.SetBatchSize(taskName, *config.ThreadCount)
+		if err != nil {
+			log.Errorw("Invalid thread count", log.TaskTypeKey, taskName, log.ErrorKey, err)
+		}
+	}
+	if config.Domain != nil {
+		c.SetDomainForTask(taskName, *config.Domain)
+	}
+	if config.Paused != nil {
+		if *config.Paused {
+			c.Pause(taskName)
+		} else {
+			c.Resume(taskName)
+		}
+	}
+}
+
+func getTaskWorkerConfigFromEnv(taskName string) taskWorkerConfig {
+	prefix := workerConfigEnvPrefix + toEnvName(taskName) + "_"
+	config := taskWorkerConfig{}
+	if value, ok := os.LookupEnv(prefix + "POLL_INTERVAL"); ok {
+		config.PollInterval = &value
+	}
+	if value, ok := os.LookupEnv(prefix + "POLL_TIMEOUT"); ok {
+		config.PollTimeout = &value
+	}
+	if value, ok := os.LookupEnv(prefix + "THREAD_COUNT"); ok {
+		threadCount, err := strconv.Atoi(value)
+		if err != nil {
+			log.Errorw(
+				"Invalid environment variable",
+				log.TaskTypeKey, taskName, "name", prefix+"THREAD_COUNT", log.ErrorKey, err,
+			)
+		} else {
+			config.ThreadCount = &threadCount
+		}
+	}
+	if value, ok := os.LookupEnv(prefix + "DOMAIN"); ok {
+		config.Domain = &value
+	}
+	if value, ok := os.LookupEnv(prefix + "PAUSED"); ok {
+		paused, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Errorw(
+				"Invalid environment variable",
+				log.TaskTypeKey, taskName, "name", prefix+"PAUSED", log.ErrorKey, err,
+			)
+		} else {
+			config.Paused = &paused
+		}
+	}
+	return config
+}
+
+func toEnvName(taskName string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(taskName))
+}
+
+func parseWorkerConfigDuration(value string) (time.Duration, error) {
+	milliseconds, err := strconv.Atoi(value)
+	if err == nil {
+		return time.Duration(milliseconds) * time.Millisecond, nil
+	}
+	return time.ParseDuration(value)
+}
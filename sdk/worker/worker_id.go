@@ -0,0 +1,88 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultWorkerIdTemplate = "{hostname}"
+	podNameEnv              = "POD_NAME"
+	taskNamePlaceholder     = "{taskName}"
+)
+
+var workerIdPlaceholderRegex = regexp.MustCompile(`\{[^{}]*\}`)
+
+// SetWorkerIdTemplate sets the template of the worker ID sent when polling for tasks and updating them, which is the
+// hostname by default. The template can contain the placeholders:
+//
+//	{hostname}  the hostname of the machine
+//	{podName}   the POD_NAME environment variable, or the hostname if not set
+//	{pid}       the ID of the process
+//	{taskName}  the name of the task
+//
+// For instance "{podName}-{pid}".
+func (c *TaskRunner) SetWorkerIdTemplate(template string) error {
+	workerIdTemplate, err := resolveWorkerIdTemplate(template)
+	if err != nil {
+		return err
+	}
+	c.workerIdMutex.Lock()
+	defer c.workerIdMutex.Unlock()
+	c.workerIdTemplate = workerIdTemplate
+	return nil
+}
+
+// GetWorkerIdForTask returns the worker ID sent when polling for and updating the task with the provided taskName.
+func (c *TaskRunner) GetWorkerIdForTask(taskName string) string {
+	c.workerIdMutex.RLock()
+	defer c.workerIdMutex.RUnlock()
+	return strings.ReplaceAll(c.workerIdTemplate, taskNamePlaceholder, taskName)
+}
+
+// resolveWorkerIdTemplate replaces the placeholders of the template which do not depend on the task.
+func resolveWorkerIdTemplate(template string) (string, error) {
+	if template == "" {
+		return "", fmt.Errorf("worker ID template can not be empty")
+	}
+	hostname, _ := os.Hostname()
+	podName := os.Getenv(podNameEnv)
+	if podName == "" {
+		podName = hostname
+	}
+	values := map[string]string{
+		"{hostname}":        hostname,
+		"{podName}":         podName,
+		"{pid}":             strconv.Itoa(os.Getpid()),
+		taskNamePlaceholder: taskNamePlaceholder,
+	}
+	var err error
+	resolved := workerIdPlaceholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := values[placeholder]
+		if !ok {
+			err = fmt.Errorf("unknown placeholder %s in worker ID template %s", placeholder, template)
+		}
+		return value
+	})
+	return resolved, err
+}
+
+func mustResolveWorkerIdTemplate(template string) string {
+	resolved, err := resolveWorkerIdTemplate(template)
+	if err != nil {
+		panic(err)
+	}
+	return resolved
+}
@@ -0,0 +1,52 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// taskLog returns the logger of the workers of a task, which adds the task type and the domains they poll to every
+// message.
+func (c *TaskRunner) taskLog(taskName string) log.StructuredLogger {
+	return log.With(
+		log.TaskTypeKey, taskName,
+		log.DomainKey, formatDomains(c.GetDomainsForTask(taskName)),
+	)
+}
+
+// domainLog returns the logger of the workers of a task polling the provided domain.
+func domainLog(taskName string, domain string) log.StructuredLogger {
+	return log.With(
+		log.TaskTypeKey, taskName,
+		log.DomainKey, domain,
+	)
+}
+
+// executionLog returns the logger of the execution of a task, which adds the task type, the task id, the workflow id
+// and the domain of the task to every message.
+func executionLog(t *model.Task) log.StructuredLogger {
+	return log.With(
+		log.TaskTypeKey, t.TaskDefName,
+		log.TaskIdKey, t.TaskId,
+		log.WorkflowIdKey, t.WorkflowInstanceId,
+		log.DomainKey, t.Domain,
+	)
+}
+
+// resultLog returns the logger of the update of a task result.
+func resultLog(taskName string, taskResult *model.TaskResult) log.StructuredLogger {
+	return log.With(
+		log.TaskTypeKey, taskName,
+		log.TaskIdKey, taskResult.TaskId,
+		log.WorkflowIdKey, taskResult.WorkflowInstanceId,
+	)
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/antihax/optional"
 	"github.com/conductor-sdk/conductor-go/sdk/client"
 	"github.com/conductor-sdk/conductor-go/sdk/event/queue"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
-	log "github.com/sirupsen/logrus"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"net/http"
 	"strings"
 	"time"
@@ -36,11 +38,13 @@ func (e *WorkflowExecutor) ExecuteWorkflowWithContext(ctx context.Context, start
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "execute", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	requestId := ""
 	version := startWorkflowRequest.Version
 
-	workflowRun, _, err := e.workflowClient.ExecuteWorkflow(ctx, *startWorkflowRequest, requestId, startWorkflowRequest.Name, version, waitUntilTask)
+	workflowRun, _, err := e.workflowClient.ExecuteWorkflow(ctx, tracedRequest, requestId, startWorkflowRequest.Name, version, waitUntilTask)
 	if err != nil {
 		return nil, err
 	}
@@ -51,8 +55,10 @@ func (e *WorkflowExecutor) ExecuteWorkflowWithReturnStrategyWithContext(ctx cont
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "execute", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
-	resp, err := e.workflowClient.ExecuteWorkflowWithReturnStrategy(ctx, *startWorkflowRequest, client.ExecuteWorkflowOpts{
+	resp, err := e.workflowClient.ExecuteWorkflowWithReturnStrategy(ctx, tracedRequest, client.ExecuteWorkflowOpts{
 		ReturnStrategy:   returnStrategy,
 		RequestID:        "",
 		WaitUntilTaskRef: waitUntilTaskRef,
@@ -70,12 +76,14 @@ func (e *WorkflowExecutor) ExecuteAndGetTargetWithContext(ctx context.Context, s
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "execute", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	requestId := ""
 	version := startWorkflowRequest.Version
 	workflowRun, _, err := e.workflowClient.ExecuteAndGetTarget(
 		ctx,
-		*startWorkflowRequest,
+		tracedRequest,
 		requestId,
 		startWorkflowRequest.Name,
 		version,
@@ -93,12 +101,14 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingWorkflowWithContext(ctx context.
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "execute", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	requestId := ""
 	version := startWorkflowRequest.Version
 	workflowRun, _, err := e.workflowClient.ExecuteAndGetBlockingWorkflow(
 		ctx,
-		*startWorkflowRequest,
+		tracedRequest,
 		requestId,
 		startWorkflowRequest.Name,
 		version,
@@ -116,12 +126,14 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingTaskWithContext(ctx context.Cont
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "execute", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	requestId := ""
 	version := startWorkflowRequest.Version
 	taskRun, _, err := e.workflowClient.ExecuteAndGetBlockingTask(
 		ctx,
-		*startWorkflowRequest,
+		tracedRequest,
 		requestId,
 		startWorkflowRequest.Name,
 		version,
@@ -140,12 +152,14 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingTaskInputWithContext(ctx context
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "execute", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	requestId := ""
 	version := startWorkflowRequest.Version
 	taskRun, _, err := e.workflowClient.ExecuteAndGetBlockingTaskInput(
 		ctx,
-		*startWorkflowRequest,
+		tracedRequest,
 		requestId,
 		startWorkflowRequest.Name,
 		version,
@@ -163,14 +177,17 @@ func (e *WorkflowExecutor) StartWorkflowWithContext(ctx context.Context, startWo
 	if err := ctx.Err(); err != nil {
 		return "", err
 	}
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "start", startWorkflowRequest)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	id, _, err := e.workflowClient.StartWorkflowWithRequest(
 		ctx,
-		*startWorkflowRequest,
+		tracedRequest,
 	)
 	if err != nil {
 		return "", err
 	}
+	span.SetAttributes(attribute.String("conductor.workflow.id", id))
 	return id, nil
 }
 
@@ -317,7 +334,7 @@ func (e *WorkflowExecutor) TerminateWithContext(ctx context.Context, workflowId
 
 	if strings.TrimSpace(workflowId) == "" {
 		err := errors.New("workflow id cannot be empty when calling terminate workflow API")
-		log.Error("Failed to terminate workflow: ", err.Error())
+		log.Errorw("Failed to terminate workflow", log.ErrorKey, err)
 		return err
 	}
 	_, err := e.workflowClient.Terminate(ctx, workflowId,
@@ -334,7 +351,7 @@ func (e *WorkflowExecutor) TerminateWithContext(ctx context.Context, workflowId
 func (e *WorkflowExecutor) TerminateWithFailureWithContext(ctx context.Context, workflowId string, reason string, triggerFailureWorkflow bool) error {
 	if strings.TrimSpace(workflowId) == "" {
 		err := errors.New("workflow id cannot be empty when calling terminate workflow API")
-		log.Error("Failed to terminate workflow: ", err.Error())
+		log.Errorw("Failed to terminate workflow", log.ErrorKey, err)
 		return err
 	}
 	_, err := e.workflowClient.Terminate(ctx, workflowId,
@@ -572,11 +589,13 @@ func getTaskResultFromOutput(taskId string, workflowInstanceId string, taskExecu
 }
 
 func (e *WorkflowExecutor) executeWorkflowWithContext(ctx context.Context, workflow *model.WorkflowDef, request *model.StartWorkflowRequest) (workflowId string, err error) {
+	ctx, span, tracedRequest := startWorkflowSpan(ctx, "start", request)
+	defer func() { tracing.EndSpan(span, err) }()
 	startWorkflowRequest := model.StartWorkflowRequest{
 		Name:                            request.Name,
 		Version:                         request.Version,
 		CorrelationId:                   request.CorrelationId,
-		Input:                           request.Input,
+		Input:                           tracedRequest.Input,
 		TaskToDomain:                    request.TaskToDomain,
 		ExternalInputPayloadStoragePath: request.ExternalInputPayloadStoragePath,
 		Priority:                        request.Priority,
@@ -589,23 +608,24 @@ func (e *WorkflowExecutor) executeWorkflowWithContext(ctx context.Context, workf
 		startWorkflowRequest,
 	)
 	if err != nil {
-		log.Debug(
+		log.Debugw(
 			"Failed to start workflow",
-			", reason: ", err.Error(),
-			", name: ", request.Name,
-			", version: ", request.Version,
-			", input: ", request.Input,
-			", workflowId: ", workflowId,
-			", response: ", response,
+			log.ErrorKey, err,
+			"name", request.Name,
+			"version", request.Version,
+			"input", request.Input,
+			log.WorkflowIdKey, workflowId,
+			"response", response,
 		)
 		return "", err
 	}
-	log.Debug(
+	span.SetAttributes(attribute.String("conductor.workflow.id", workflowId))
+	log.Debugw(
 		"Started workflow",
-		", workflowId: ", workflowId,
-		", name: ", request.Name,
-		", version: ", request.Version,
-		", input: ", request.Input,
+		log.WorkflowIdKey, workflowId,
+		"name", request.Name,
+		"version", request.Version,
+		"input", request.Input,
 	)
 
 	return workflowId, err
@@ -20,7 +20,7 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 )
 
 type WorkflowMonitor struct {
@@ -58,10 +58,7 @@ func (w *WorkflowMonitor) monitorRunningWorkflowsDaemon() {
 	for {
 		err := w.monitorRunningWorkflows()
 		if err != nil {
-			log.Warning(
-				"Failed to monitor running workflows",
-				", error: ", err.Error(),
-			)
+			log.Warnw("Failed to monitor running workflows", log.ErrorKey, err)
 		}
 		time.Sleep(w.refreshInterval)
 	}
@@ -94,11 +91,11 @@ func (w *WorkflowMonitor) getWorkflowsInTerminalState() ([]*model.Workflow, erro
 			&client.WorkflowResourceApiGetExecutionStatusOpts{IncludeTasks: optional.NewBool(false)},
 		)
 		if err != nil {
-			log.Debug(
+			log.Debugw(
 				"Failed to get workflow execution status",
-				", reason: ", err.Error(),
-				", workflowId: ", workflowId,
-				", response: ", response,
+				log.ErrorKey, err,
+				log.WorkflowIdKey, workflowId,
+				"response", response,
 			)
 			return nil, err
 		}
@@ -113,12 +110,7 @@ func (w *WorkflowMonitor) addWorkflowExecutionChannel(workflowId string, executi
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 	w.executionChannelByWorkflowId[workflowId] = executionChannel
-	log.Debug(
-		fmt.Sprint(
-			"Added workflow execution channel",
-			", workflowId: ", workflowId,
-		),
-	)
+	log.Debugw("Added workflow execution channel", log.WorkflowIdKey, workflowId)
 	return nil
 }
 
@@ -137,17 +129,17 @@ func (w *WorkflowMonitor) getRunningWorkflowIdList() ([]string, error) {
 func (w *WorkflowMonitor) notifyFinishedWorkflow(workflowId string, workflow *model.Workflow) error {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
-	log.Debug(fmt.Sprintf("Notifying finished workflowId: %s", workflowId))
+	log.Debugw("Notifying finished workflow", log.WorkflowIdKey, workflowId)
 	executionChannel, ok := w.executionChannelByWorkflowId[workflowId]
 	if !ok {
 		return fmt.Errorf("execution channel not found for workflowId: %s", workflowId)
 	}
 	executionChannel <- workflow
-	log.Debug("Sent finished workflow through channel")
+	log.Debugw("Sent finished workflow through channel", log.WorkflowIdKey, workflowId)
 	close(executionChannel)
-	log.Debug("Closed client workflow execution channel")
+	log.Debugw("Closed client workflow execution channel", log.WorkflowIdKey, workflowId)
 	delete(w.executionChannelByWorkflowId, workflowId)
-	log.Debug("Deleted workflow execution channel")
+	log.Debugw("Deleted workflow execution channel", log.WorkflowIdKey, workflowId)
 	return nil
 }
 
@@ -0,0 +1,36 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startWorkflowSpan starts the span of a call starting a workflow. It returns a copy of the request with the trace
+// context of the span in its input, for the tasks of the workflow to be traced as part of it.
+func startWorkflowSpan(ctx context.Context, spanName string, request *model.StartWorkflowRequest) (context.Context, trace.Span, model.StartWorkflowRequest) {
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		spanName+" "+request.Name,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("conductor.workflow.name", request.Name),
+			attribute.Int("conductor.workflow.version", int(request.Version)),
+		),
+	)
+	tracedRequest := *request
+	tracedRequest.Input = tracing.InjectIntoInput(ctx, request.Input)
+	return ctx, span, tracedRequest
+}
@@ -11,9 +11,9 @@ package workflow
 
 import (
 	"encoding/json"
+	"github.com/conductor-sdk/conductor-go/sdk/log"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
-	log "github.com/sirupsen/logrus"
 )
 
 type TimeoutPolicy string
@@ -275,10 +275,7 @@ func getInputAsMap(input interface{}) map[string]interface{} {
 	}
 	data, err := json.Marshal(input)
 	if err != nil {
-		log.Debug(
-			"Failed to parse input",
-			", reason: ", err.Error(),
-		)
+		log.Debugw("Failed to parse input", log.ErrorKey, err)
 		return nil
 	}
 	var parsedInput map[string]interface{}
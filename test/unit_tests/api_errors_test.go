@@ -0,0 +1,100 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// newErrorServer returns a server which responds to GET /metadata/taskdefs/{statusCode} with the status code, and the
+// provided body.
+func newErrorServer(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statusCode, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/metadata/taskdefs/"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newNonRetryingMetadataClient returns a MetadataClient whose failed requests are returned without being retried.
+func newNonRetryingMetadataClient(url string) client.MetadataClient {
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(url))
+	apiClient.SetRetryPolicy(nil)
+	return client.NewMetadataClient(apiClient)
+}
+
+func TestAPIErrorsMatchSentinels(t *testing.T) {
+	server := newErrorServer(t, `{"status":0,"message":"failed"}`)
+	metadataClient := newNonRetryingMetadataClient(server.URL)
+	for statusCode, sentinel := range map[int]error{
+		http.StatusBadRequest:          client.ErrBadRequest,
+		http.StatusUnauthorized:        client.ErrUnauthorized,
+		http.StatusForbidden:           client.ErrForbidden,
+		http.StatusNotFound:            client.ErrNotFound,
+		http.StatusConflict:            client.ErrConflict,
+		http.StatusTooManyRequests:     client.ErrRateLimited,
+		http.StatusInternalServerError: client.ErrServerError,
+		http.StatusServiceUnavailable:  client.ErrServiceUnavailable,
+	} {
+		_, _, err := metadataClient.GetTaskDef(context.Background(), strconv.Itoa(statusCode))
+		assert.True(t, errors.Is(err, sentinel), "status code %d should match %s", statusCode, sentinel)
+		assert.False(t, errors.Is(err, client.ErrConflict) && statusCode != http.StatusConflict)
+	}
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "503")
+	assert.True(t, errors.Is(err, client.ErrServerError))
+}
+
+func TestAPIErrorDecodesConductorErrorBody(t *testing.T) {
+	server := newErrorServer(t, `{
+		"status": 400,
+		"message": "Validation failed",
+		"retryable": false,
+		"validationErrors": [{"path": "registerTaskDef.taskDefinition.name", "message": "name cannot be empty"}]
+	}`)
+	metadataClient := newNonRetryingMetadataClient(server.URL)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "400")
+
+	var apiErr client.GenericSwaggerError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	assert.Equal(t, http.MethodGet, apiErr.Method())
+	assert.Equal(t, "/metadata/taskdefs/400", apiErr.Path())
+	assert.Equal(t, "Validation failed", apiErr.Message())
+	assert.False(t, apiErr.IsRetryable())
+	if assert.Len(t, apiErr.ValidationErrors(), 1) {
+		assert.Equal(t, "registerTaskDef.taskDefinition.name", apiErr.ValidationErrors()[0].Path)
+		assert.Equal(t, "name cannot be empty", apiErr.ValidationErrors()[0].Message)
+	}
+}
+
+func TestAPIErrorWithoutConductorErrorBody(t *testing.T) {
+	server := newErrorServer(t, `upstream unavailable`)
+	metadataClient := newNonRetryingMetadataClient(server.URL)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "502")
+
+	var apiErr client.GenericSwaggerError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Nil(t, apiErr.ErrorResponse())
+	assert.Equal(t, "upstream unavailable", apiErr.Message())
+	assert.True(t, apiErr.IsRetryable())
+}
@@ -0,0 +1,185 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyServer fails the first requests it receives with a status code, and then succeeds.
+type flakyServer struct {
+	*httptest.Server
+	mutex      sync.Mutex
+	failures   int
+	statusCode int
+	retryAfter string
+	requests   []*http.Request
+	bodies     []string
+}
+
+func newFlakyServer(t *testing.T, failures int, statusCode int) *flakyServer {
+	server := &flakyServer{failures: failures, statusCode: statusCode}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *flakyServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, r)
+	s.bodies = append(s.bodies, string(body))
+	w.Header().Set("Content-Type", "application/json")
+	if len(s.requests) <= s.failures {
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		w.WriteHeader(s.statusCode)
+		return
+	}
+	if r.URL.Path == "/workflow" {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("workflow-id"))
+		return
+	}
+	w.Write([]byte(`{"name": "task"}`))
+}
+
+func (s *flakyServer) getRequests() []*http.Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*http.Request{}, s.requests...)
+}
+
+func (s *flakyServer) getBodies() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.bodies...)
+}
+
+func newFastAPIRetryPolicy() *client.RetryPolicy {
+	policy := client.NewRetryPolicy()
+	policy.InitialInterval = time.Millisecond
+	policy.MaxInterval = 10 * time.Millisecond
+	return policy
+}
+
+func newRetryingAPIClient(url string) *client.APIClient {
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(url))
+	apiClient.SetRetryPolicy(newFastAPIRetryPolicy())
+	return apiClient
+}
+
+func TestRequestsNotRetriedByDefault(t *testing.T) {
+	server := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	assert.Nil(t, apiClient.GetRetryPolicy())
+	_, _, err := client.NewMetadataClient(apiClient).GetTaskDef(context.Background(), "task")
+	assert.True(t, errors.Is(err, client.ErrServiceUnavailable))
+	assert.Len(t, server.getRequests(), 1)
+}
+
+func TestIdempotentRequestIsRetried(t *testing.T) {
+	server := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+	metadataClient := client.NewMetadataClient(newRetryingAPIClient(server.URL))
+	taskDef, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Equal(t, "task", taskDef.Name)
+	assert.Len(t, server.getRequests(), 3)
+}
+
+func TestRetriesGiveUpAfterMaxRetries(t *testing.T) {
+	server := newFlakyServer(t, 10, http.StatusBadGateway)
+	metadataClient := client.NewMetadataClient(newRetryingAPIClient(server.URL))
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.True(t, errors.Is(err, client.ErrServerError))
+	assert.Len(t, server.getRequests(), 4)
+}
+
+func TestNonRetryableStatusCodeIsNotRetried(t *testing.T) {
+	server := newFlakyServer(t, 1, http.StatusInternalServerError)
+	metadataClient := client.NewMetadataClient(newRetryingAPIClient(server.URL))
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.Error(t, err)
+	assert.Len(t, server.getRequests(), 1)
+}
+
+func TestNonIdempotentRequestIsRetriedOnlyWithIdempotencyKey(t *testing.T) {
+	server := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	workflowClient := client.NewWorkflowClient(newRetryingAPIClient(server.URL))
+	_, _, err := workflowClient.StartWorkflowWithRequest(context.Background(), model.StartWorkflowRequest{Name: "workflow"})
+	assert.True(t, errors.Is(err, client.ErrServiceUnavailable))
+	assert.Len(t, server.getRequests(), 1)
+
+	server = newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	workflowClient = client.NewWorkflowClient(newRetryingAPIClient(server.URL))
+	workflowId, _, err := workflowClient.StartWorkflowWithRequest(
+		context.Background(),
+		model.StartWorkflowRequest{Name: "workflow", IdempotencyKey: "key"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "workflow-id", workflowId)
+	requests := server.getRequests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "key", requests[1].Header.Get(client.IdempotencyKeyHeader))
+		bodies := server.getBodies()
+		assert.Equal(t, bodies[0], bodies[1])
+		assert.Contains(t, bodies[1], `"name":"workflow"`)
+	}
+}
+
+func TestRetryAfterIsHonored(t *testing.T) {
+	server := newFlakyServer(t, 1, http.StatusTooManyRequests)
+	server.retryAfter = "1"
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	policy := newFastAPIRetryPolicy()
+	policy.MaxInterval = 2 * time.Second
+	apiClient.SetRetryPolicy(policy)
+	start := time.Now()
+	_, _, err := client.NewMetadataClient(apiClient).GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.Len(t, server.getRequests(), 2)
+
+	server = newFlakyServer(t, 1, http.StatusTooManyRequests)
+	server.retryAfter = "60"
+	_, _, err = client.NewMetadataClient(newRetryingAPIClient(server.URL)).GetTaskDef(context.Background(), "task")
+	assert.True(t, errors.Is(err, client.ErrRateLimited))
+	assert.Len(t, server.getRequests(), 1)
+}
+
+func TestRetryPolicyOverriddenThroughContext(t *testing.T) {
+	server := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	metadataClient := client.NewMetadataClient(newRetryingAPIClient(server.URL))
+	_, _, err := metadataClient.GetTaskDef(client.WithRetryPolicy(context.Background(), nil), "task")
+	assert.True(t, errors.Is(err, client.ErrServiceUnavailable))
+	assert.Len(t, server.getRequests(), 1)
+
+	server = newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	apiClient.SetRetryPolicy(nil)
+	ctx := client.WithRetryPolicy(context.Background(), newFastAPIRetryPolicy())
+	_, _, err = client.NewMetadataClient(apiClient).GetTaskDef(ctx, "task")
+	assert.NoError(t, err)
+	assert.Len(t, server.getRequests(), 2)
+}
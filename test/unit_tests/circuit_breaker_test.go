@@ -0,0 +1,141 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerPausesPollingOnFailures(t *testing.T) {
+	server := newConductorServerMock(t)
+	enqueueTasks(server, "circuit_breaker", 6)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetCircuitBreakerForTask("circuit_breaker", &worker.CircuitBreakerSettings{
+		FailureRateThreshold: 0.5,
+		Window:               4,
+		MinimumExecutions:    2,
+		Cooldown:             500 * time.Millisecond,
+	}))
+	failing := int32(1)
+	taskRunner.StartWorkerWithContext("circuit_breaker", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		if atomic.LoadInt32(&failing) == 1 {
+			return nil, fmt.Errorf("downstream is unavailable")
+		}
+		return nil, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("circuit_breaker")
+
+	server.waitForResults(2, 5*time.Second)
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetCircuitBreakerStateForTask("circuit_breaker") == worker.CircuitBreakerOpen
+	}, 5*time.Second, 10*time.Millisecond)
+	polls := server.getPolls("circuit_breaker")
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, polls, server.getPolls("circuit_breaker"))
+	assert.Len(t, server.getResults(), 2)
+
+	atomic.StoreInt32(&failing, 0)
+	results := server.waitForResults(6, 5*time.Second)
+	assert.Len(t, results, 6)
+	assert.Equal(t, model.CompletedTask, results[5].Status)
+	assert.Equal(t, worker.CircuitBreakerClosed, taskRunner.GetCircuitBreakerStateForTask("circuit_breaker"))
+}
+
+func TestCircuitBreakerSettingsValidated(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	settings := worker.NewCircuitBreakerSettings()
+	assert.Nil(t, taskRunner.SetCircuitBreakerForTask("circuit_breaker_settings", settings))
+	settings.MinimumExecutions = settings.Window + 1
+	assert.NotNil(t, taskRunner.SetCircuitBreakerForTask("circuit_breaker_settings", settings))
+	assert.Nil(t, taskRunner.SetCircuitBreakerForTask("circuit_breaker_settings", nil))
+}
+
+func TestCircuitBreakerTrialReleasedWhenInterceptorSkipsTask(t *testing.T) {
+	server := newConductorServerMock(t)
+	enqueueTasks(server, "circuit_breaker_skipped_trial", 5)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetCircuitBreakerForTask("circuit_breaker_skipped_trial", &worker.CircuitBreakerSettings{
+		FailureRateThreshold: 0.5,
+		Window:               2,
+		MinimumExecutions:    2,
+		Cooldown:             200 * time.Millisecond,
+	}))
+	failing := int32(1)
+	taskRunner.StartWorkerWithContext("circuit_breaker_skipped_trial", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		if atomic.LoadInt32(&failing) == 1 {
+			return nil, fmt.Errorf("downstream is unavailable")
+		}
+		return nil, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("circuit_breaker_skipped_trial")
+
+	server.waitForResults(2, 5*time.Second)
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetCircuitBreakerStateForTask("circuit_breaker_skipped_trial") == worker.CircuitBreakerOpen
+	}, 5*time.Second, 10*time.Millisecond)
+	atomic.StoreInt32(&failing, 0)
+	// the trial task is dropped without a result
+	var skipped atomic.Int32
+	taskRunner.AddExecuteInterceptorForTask("circuit_breaker_skipped_trial", func(ctx context.Context, task *model.Task, next worker.ExecuteHandler) *model.TaskResult {
+		if skipped.Add(1) == 1 {
+			return nil
+		}
+		return next(ctx, task)
+	})
+
+	results := server.waitForResults(4, 5*time.Second)
+	assert.Len(t, results, 4)
+	assert.Equal(t, model.CompletedTask, results[2].Status)
+	assert.Equal(t, worker.CircuitBreakerClosed, taskRunner.GetCircuitBreakerStateForTask("circuit_breaker_skipped_trial"))
+}
+
+func TestCircuitBreakerPollingResumesSoonAfterTrial(t *testing.T) {
+	server := newConductorServerMock(t)
+	enqueueTasks(server, "circuit_breaker_trial", 4)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetCircuitBreakerForTask("circuit_breaker_trial", &worker.CircuitBreakerSettings{
+		FailureRateThreshold: 1,
+		Window:               1,
+		MinimumExecutions:    1,
+		Cooldown:             time.Second,
+	}))
+	failing := int32(1)
+	taskRunner.StartWorkerWithContext("circuit_breaker_trial", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		if atomic.LoadInt32(&failing) == 1 {
+			return nil, fmt.Errorf("downstream is unavailable")
+		}
+		time.Sleep(100 * time.Millisecond)
+		return nil, nil
+	}, 2, 10*time.Millisecond)
+	defer taskRunner.Shutdown("circuit_breaker_trial")
+
+	server.waitForResults(2, 5*time.Second)
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetCircuitBreakerStateForTask("circuit_breaker_trial") == worker.CircuitBreakerOpen
+	}, 5*time.Second, 10*time.Millisecond)
+	atomic.StoreInt32(&failing, 0)
+
+	// the poller checks the circuit breaker while the trial task runs, and polls again once it closes rather than after
+	// another cooldown
+	server.waitForResults(3, 5*time.Second)
+	results := server.waitForResults(4, 500*time.Millisecond)
+	assert.Len(t, results, 4)
+	assert.Equal(t, model.CompletedTask, results[3].Status)
+	assert.Equal(t, worker.CircuitBreakerClosed, taskRunner.GetCircuitBreakerStateForTask("circuit_breaker_trial"))
+}
@@ -0,0 +1,344 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+// conductorServerMock is a minimal in-memory Conductor server which hands out queued tasks on batch poll and records
+// the task results it receives.
+type conductorServerMock struct {
+	*httptest.Server
+
+	mutex   sync.Mutex
+	queue   map[string][]model.Task
+	results []model.TaskResult
+	// polled holds the tasks handed out by task id, which are queued again when updated as IN_PROGRESS.
+	polled map[string]model.Task
+	// updateStatusCode, when set, is returned for task updates instead of accepting them.
+	updateStatusCode int
+	updateAttempts   int
+	polls            map[string]int
+	pollRequests     []url.Values
+	logs             map[string][]string
+	taskDefs         map[string]model.TaskDef
+	taskDefUpdates   int
+	// batchUpdates enables the batch update endpoint, which is missing otherwise.
+	batchUpdates bool
+	// batchUpdateStatusCode, when set, is returned for non-empty batch updates instead of accepting them.
+	batchUpdateStatusCode int
+	batchUpdateSizes      []int
+	startRequests         []*http.Request
+	startBodies           []model.StartWorkflowRequest
+}
+
+func newConductorServerMock(t *testing.T) *conductorServerMock {
+	mock := &conductorServerMock{
+		queue:    make(map[string][]model.Task),
+		polled:   make(map[string]model.Task),
+		polls:    make(map[string]int),
+		logs:     make(map[string][]string),
+		taskDefs: make(map[string]model.TaskDef),
+	}
+	mock.Server = httptest.NewServer(http.HandlerFunc(mock.handle))
+	t.Cleanup(mock.Close)
+	return mock
+}
+
+func (m *conductorServerMock) newTaskRunner() *worker.TaskRunner {
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(m.URL))
+	return worker.NewTaskRunnerWithApiClient(apiClient)
+}
+
+func (m *conductorServerMock) enqueue(tasks ...model.Task) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, task := range tasks {
+		m.queue[task.TaskDefName] = append(m.queue[task.TaskDefName], task)
+	}
+}
+
+func (m *conductorServerMock) setUpdateStatusCode(statusCode int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.updateStatusCode = statusCode
+}
+
+func (m *conductorServerMock) getUpdateAttempts() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.updateAttempts
+}
+
+func (m *conductorServerMock) getPolls(taskName string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.polls[taskName]
+}
+
+func (m *conductorServerMock) getLogs(taskId string) []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string(nil), m.logs[taskId]...)
+}
+
+func (m *conductorServerMock) putTaskDef(taskDef model.TaskDef) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.taskDefs[taskDef.Name] = taskDef
+}
+
+func (m *conductorServerMock) getTaskDef(name string) (model.TaskDef, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	taskDef, ok := m.taskDefs[name]
+	return taskDef, ok
+}
+
+func (m *conductorServerMock) getTaskDefUpdates() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.taskDefUpdates
+}
+
+func (m *conductorServerMock) enableBatchUpdates() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.batchUpdates = true
+}
+
+func (m *conductorServerMock) setBatchUpdateStatusCode(statusCode int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.batchUpdateStatusCode = statusCode
+}
+
+func (m *conductorServerMock) getBatchUpdateSizes() []int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]int(nil), m.batchUpdateSizes...)
+}
+
+func (m *conductorServerMock) getPollRequests() []url.Values {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]url.Values(nil), m.pollRequests...)
+}
+
+func (m *conductorServerMock) getStartRequests() ([]*http.Request, []model.StartWorkflowRequest) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]*http.Request(nil), m.startRequests...), append([]model.StartWorkflowRequest(nil), m.startBodies...)
+}
+
+func (m *conductorServerMock) getResults() []model.TaskResult {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]model.TaskResult(nil), m.results...)
+}
+
+// waitForResults blocks until at least count task results were received, or the timeout elapses.
+func (m *conductorServerMock) waitForResults(count int, timeout time.Duration) []model.TaskResult {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if results := m.getResults(); len(results) >= count {
+			return results
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return m.getResults()
+}
+
+func (m *conductorServerMock) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/tasks/poll/batch/"):
+		m.handleBatchPoll(w, r, strings.TrimPrefix(r.URL.Path, "/tasks/poll/batch/"))
+	case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+		m.handleUpdateTask(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/tasks/batch":
+		m.handleUpdateTasks(w, r)
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/tasks/") && strings.HasSuffix(r.URL.Path, "/log"):
+		m.handleLog(w, r, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/log"))
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/metadata/taskdefs/"):
+		m.handleGetTaskDef(w, strings.TrimPrefix(r.URL.Path, "/metadata/taskdefs/"))
+	case r.Method == http.MethodPost && r.URL.Path == "/metadata/taskdefs":
+		m.handleRegisterTaskDefs(w, r)
+	case r.Method == http.MethodPut && r.URL.Path == "/metadata/taskdefs":
+		m.handleUpdateTaskDef(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/workflow":
+		m.handleStartWorkflow(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// handleBatchPoll hands out up to count queued tasks of the polled domain.
+func (m *conductorServerMock) handleBatchPoll(w http.ResponseWriter, r *http.Request, taskName string) {
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	domain := r.URL.Query().Get("domain")
+	m.mutex.Lock()
+	m.polls[taskName] += 1
+	m.pollRequests = append(m.pollRequests, r.URL.Query())
+	var tasks, remaining []model.Task
+	for _, task := range m.queue[taskName] {
+		if task.Domain == domain && len(tasks) < count {
+			tasks = append(tasks, task)
+		} else {
+			remaining = append(remaining, task)
+		}
+	}
+	m.queue[taskName] = remaining
+	for _, task := range tasks {
+		m.polled[task.TaskId] = task
+	}
+	m.mutex.Unlock()
+	if len(tasks) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
+}
+
+func (m *conductorServerMock) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	var taskResult model.TaskResult
+	if err := json.NewDecoder(r.Body).Decode(&taskResult); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	m.mutex.Lock()
+	m.updateAttempts += 1
+	statusCode := m.updateStatusCode
+	if statusCode == 0 {
+		m.results = append(m.results, taskResult)
+		m.requeue(taskResult)
+	}
+	m.mutex.Unlock()
+	if statusCode != 0 {
+		w.WriteHeader(statusCode)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(taskResult.TaskId))
+}
+
+// requeue queues the task again when its result hands it back, as IN_PROGRESS without lease extension nor callback
+// delay.
+func (m *conductorServerMock) requeue(taskResult model.TaskResult) {
+	task, ok := m.polled[taskResult.TaskId]
+	if !ok || taskResult.Status != model.InProgressTask || taskResult.ExtendLease || taskResult.CallbackAfterSeconds > 0 {
+		return
+	}
+	m.queue[task.TaskDefName] = append(m.queue[task.TaskDefName], task)
+}
+
+func (m *conductorServerMock) handleLog(w http.ResponseWriter, r *http.Request, taskId string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	message := string(body)
+	json.Unmarshal(body, &message)
+	m.mutex.Lock()
+	m.logs[taskId] = append(m.logs[taskId], message)
+	m.mutex.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *conductorServerMock) handleGetTaskDef(w http.ResponseWriter, name string) {
+	taskDef, ok := m.getTaskDef(name)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taskDef)
+}
+
+func (m *conductorServerMock) handleRegisterTaskDefs(w http.ResponseWriter, r *http.Request) {
+	var taskDefs []model.TaskDef
+	if err := json.NewDecoder(r.Body).Decode(&taskDefs); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, taskDef := range taskDefs {
+		m.putTaskDef(taskDef)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *conductorServerMock) handleUpdateTaskDef(w http.ResponseWriter, r *http.Request) {
+	var taskDef model.TaskDef
+	if err := json.NewDecoder(r.Body).Decode(&taskDef); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	m.mutex.Lock()
+	if existing, ok := m.taskDefs[taskDef.Name]; ok && !taskDef.OverwriteTags {
+		taskDef.Tags = existing.Tags
+	}
+	m.taskDefs[taskDef.Name] = taskDef
+	m.taskDefUpdates += 1
+	m.mutex.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *conductorServerMock) handleUpdateTasks(w http.ResponseWriter, r *http.Request) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.batchUpdates {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var taskResults []model.TaskResult
+	if err := json.NewDecoder(r.Body).Decode(&taskResults); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if m.batchUpdateStatusCode != 0 && len(taskResults) > 0 {
+		w.WriteHeader(m.batchUpdateStatusCode)
+		return
+	}
+	m.batchUpdateSizes = append(m.batchUpdateSizes, len(taskResults))
+	m.results = append(m.results, taskResults...)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *conductorServerMock) handleStartWorkflow(w http.ResponseWriter, r *http.Request) {
+	var request model.StartWorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	m.mutex.Lock()
+	m.startRequests = append(m.startRequests, r)
+	m.startBodies = append(m.startBodies, request)
+	workflowId := fmt.Sprintf("workflow-%d", len(m.startBodies))
+	m.mutex.Unlock()
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(workflowId))
+}
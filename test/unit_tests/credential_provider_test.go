@@ -0,0 +1,114 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/authentication"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticCredentialProvider(t *testing.T) {
+	server := newAuthServer(t, time.Hour)
+	apiClient := client.NewAPIClientWithCredentialProvider(
+		settings.NewHttpSettings(server.URL),
+		authentication.NewStaticCredentialProvider("static-token"),
+	)
+	_, _, err := client.NewMetadataClient(apiClient).GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"static-token"}, server.getAPITokens())
+	assert.Empty(t, server.getTokens())
+}
+
+func TestFileCredentialProviderReloadsChangedFile(t *testing.T) {
+	server := newAuthServer(t, time.Hour)
+	path := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(path, []byte("first-token\n"), 0600))
+	apiClient := client.NewAPIClientWithCredentialProvider(
+		settings.NewHttpSettings(server.URL),
+		authentication.NewFileCredentialProviderWithReloadInterval(path, 100*time.Millisecond),
+	)
+	metadataClient := client.NewMetadataClient(apiClient)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first-token"}, server.getAPITokens())
+
+	assert.NoError(t, os.WriteFile(path, []byte("second-token\n"), 0600))
+	assert.Eventually(t, func() bool {
+		_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+		apiTokens := server.getAPITokens()
+		return err == nil && apiTokens[len(apiTokens)-1] == "second-token"
+	}, 2*time.Second, 50*time.Millisecond)
+}
+
+func TestOAuth2CredentialProvider(t *testing.T) {
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientId, clientSecret, ok := r.BasicAuth()
+		r.ParseForm()
+		if !ok || clientId != "client" || clientSecret != "secret" ||
+			r.PostForm.Get("grant_type") != "client_credentials" ||
+			r.PostForm.Get("scope") != "read write" ||
+			r.PostForm.Get("audience") != "conductor" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "oauth-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	t.Cleanup(issuer.Close)
+	server := newAuthServer(t, time.Hour)
+
+	provider := authentication.NewOAuth2CredentialProvider(issuer.URL, "client", "secret", "read", "write")
+	provider.EndpointParams = url.Values{"audience": {"conductor"}}
+	token, expiry, err := provider.RetrieveToken(nil, http.DefaultClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "oauth-token", token)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiry, 5*time.Second)
+
+	apiClient := client.NewAPIClientWithCredentialProvider(settings.NewHttpSettings(server.URL), provider)
+	_, _, err = client.NewMetadataClient(apiClient).GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"oauth-token"}, server.getAPITokens())
+
+	provider.ClientSecret = "wrong"
+	_, _, err = provider.RetrieveToken(nil, http.DefaultClient)
+	assert.ErrorContains(t, err, "invalid_client")
+}
+
+func TestChainCredentialProvider(t *testing.T) {
+	missingFile := filepath.Join(t.TempDir(), "missing")
+	provider := authentication.NewChainCredentialProvider(
+		authentication.NewFileCredentialProvider(missingFile),
+		authentication.NewKeySecretCredentialProvider(*settings.NewAuthenticationSettings("", "")),
+		authentication.NewStaticCredentialProvider("static-token"),
+	)
+	token, _, err := provider.RetrieveToken(nil, http.DefaultClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "static-token", token)
+
+	provider = authentication.NewChainCredentialProvider(
+		authentication.NewFileCredentialProvider(missingFile),
+		authentication.NewStaticCredentialProvider(""),
+	)
+	_, _, err = provider.RetrieveToken(nil, http.DefaultClient)
+	assert.ErrorContains(t, err, "failed to read token file")
+	assert.ErrorContains(t, err, "no token set")
+}
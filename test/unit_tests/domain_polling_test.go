@@ -0,0 +1,80 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerIdTemplate(t *testing.T) {
+	t.Setenv("POD_NAME", "worker-pod-1")
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{TaskDefName: "worker_id", TaskId: "task-1", WorkflowInstanceId: "workflow-1"})
+	taskRunner := server.newTaskRunner()
+	hostname, _ := os.Hostname()
+	assert.Equal(t, hostname, taskRunner.GetWorkerIdForTask("worker_id"))
+	assert.NotNil(t, taskRunner.SetWorkerIdTemplate("{podName}-{unknown}"))
+	assert.Nil(t, taskRunner.SetWorkerIdTemplate("{podName}-{pid}-{taskName}"))
+	workerId := "worker-pod-1-" + strconv.Itoa(os.Getpid()) + "-worker_id"
+	assert.Equal(t, workerId, taskRunner.GetWorkerIdForTask("worker_id"))
+
+	taskRunner.StartWorkerWithContext("worker_id", noopWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("worker_id")
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, workerId, results[0].WorkerId)
+	assert.Equal(t, workerId, server.getPollRequests()[0].Get("workerid"))
+}
+
+func TestWeightedDomainPolling(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.NotNil(t, taskRunner.SetWeightedDomainsForTask("weighted_domains", map[string]int{"blue": 0}))
+	assert.Nil(t, taskRunner.SetWeightedDomainsForTask("weighted_domains", map[string]int{"blue": 3, "green": 1}))
+	taskRunner.StartWorkerWithDomainAndContext("weighted_domains", noopWorker, 1, 5*time.Millisecond, "ignored")
+	assert.Eventually(t, func() bool {
+		return len(server.getPollRequests()) >= 8
+	}, 5*time.Second, 5*time.Millisecond)
+	taskRunner.Shutdown("weighted_domains")
+
+	pollsByDomain := make(map[string]int)
+	for _, pollRequest := range server.getPollRequests()[:8] {
+		pollsByDomain[pollRequest.Get("domain")] += 1
+	}
+	assert.Equal(t, map[string]int{"blue": 6, "green": 2}, pollsByDomain)
+}
+
+func TestFallbackDomainPolling(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(
+		model.Task{TaskDefName: "fallback_domains", TaskId: "task-1", WorkflowInstanceId: "workflow-1", Domain: "tenant"},
+		model.Task{TaskDefName: "fallback_domains", TaskId: "task-2", WorkflowInstanceId: "workflow-1"},
+		model.Task{TaskDefName: "fallback_domains", TaskId: "task-3", WorkflowInstanceId: "workflow-1"},
+	)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetFallbackDomainsForTask("fallback_domains", "tenant", ""))
+	taskRunner.StartWorkerWithContext("fallback_domains", noopWorker, 2, 10*time.Millisecond)
+	defer taskRunner.Shutdown("fallback_domains")
+
+	results := server.waitForResults(3, 5*time.Second)
+	assert.Len(t, results, 3)
+	pollRequests := server.getPollRequests()
+	assert.Equal(t, "tenant", pollRequests[0].Get("domain"))
+	assert.Equal(t, "2", pollRequests[0].Get("count"))
+	assert.Equal(t, "", pollRequests[1].Get("domain"))
+	assert.Equal(t, "1", pollRequests[1].Get("count"))
+	assert.Equal(t, []string{"tenant", ""}, taskRunner.GetDomainsForTask("fallback_domains"))
+}
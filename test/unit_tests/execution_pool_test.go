@@ -0,0 +1,94 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionPoolSettingsValidation(t *testing.T) {
+	taskRunner := newConductorServerMock(t).newTaskRunner()
+	assert.NotNil(t, taskRunner.SetExecutionPoolForTask("pooled_task", 0, 1))
+	assert.NotNil(t, taskRunner.SetExecutionPoolForTask("pooled_task", 1, -1))
+	assert.Nil(t, taskRunner.SetExecutionPoolForTask("pooled_task", 1, 0))
+}
+
+func TestExecutionPoolBoundsConcurrency(t *testing.T) {
+	server := newConductorServerMock(t)
+	for i := 0; i < 6; i++ {
+		server.enqueue(model.Task{
+			TaskDefName:        "pooled_task",
+			TaskId:             fmt.Sprintf("task-%d", i),
+			WorkflowInstanceId: "workflow-1",
+		})
+	}
+	var running, maxRunning int32
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetExecutionPoolForTask("pooled_task", 2, 1))
+	taskRunner.StartWorker("pooled_task", func(task *model.Task) (interface{}, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+		return map[string]interface{}{}, nil
+	}, 10, 10*time.Millisecond)
+
+	results := server.waitForResults(6, 5*time.Second)
+	taskRunner.Shutdown("pooled_task")
+	taskRunner.WaitWorkers()
+	assert.Len(t, results, 6)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestExecutionQueueFullOnlyCountedWhenPoolIsFull(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "queue_full_task",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	registry := prometheus.NewRegistry()
+	recorder, err := metrics.NewPrometheusRecorder(registry)
+	assert.Nil(t, err)
+	release := make(chan struct{})
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetMetricsRecorder(recorder)
+	assert.Nil(t, taskRunner.SetExecutionPoolForTask("queue_full_task", 1, 0))
+	taskRunner.StartWorker("queue_full_task", func(task *model.Task) (interface{}, error) {
+		<-release
+		return map[string]interface{}{}, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("queue_full_task")
+
+	assert.Eventually(t, func() bool {
+		return getCounterValue(t, registry, string(metrics.TASK_EXECUTION_QUEUE_FULL), "queue_full_task") > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	close(release)
+	server.waitForResults(1, 5*time.Second)
+
+	assert.Nil(t, taskRunner.SetBatchSize("queue_full_task", 0))
+	queueFull := getCounterValue(t, registry, string(metrics.TASK_EXECUTION_QUEUE_FULL), "queue_full_task")
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, queueFull, getCounterValue(t, registry, string(metrics.TASK_EXECUTION_QUEUE_FULL), "queue_full_task"),
+		"a stopped worker is not reported as having a full execution queue")
+}
@@ -0,0 +1,87 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveHealth(handler http.Handler, method string, path string, body string) (int, worker.HealthStatus) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	var status worker.HealthStatus
+	json.Unmarshal(recorder.Body.Bytes(), &status)
+	return recorder.Code, status
+}
+
+func TestHealthHandlerReportsReadiness(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	handler := taskRunner.HealthHandler(false)
+
+	statusCode, _ := serveHealth(handler, http.MethodGet, "/ready", "")
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+
+	taskRunner.StartWorkerWithContext("health_ready", noopWorker, 2, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		statusCode, _ := serveHealth(handler, http.MethodGet, "/ready", "")
+		return statusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	statusCode, status := serveHealth(handler, http.MethodGet, "/workers", "")
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.True(t, status.Live)
+	assert.Len(t, status.Tasks, 1)
+	assert.Equal(t, "health_ready", status.Tasks[0].TaskName)
+	assert.Equal(t, 2, status.Tasks[0].BatchSize)
+	assert.NotNil(t, status.Tasks[0].LastSuccessfulPoll)
+
+	statusCode, _ = serveHealth(handler, http.MethodPost, "/workers/health_ready/pause", "")
+	assert.Equal(t, http.StatusNotFound, statusCode)
+
+	taskRunner.ShutdownAll(context.Background())
+	statusCode, _ = serveHealth(handler, http.MethodGet, "/live", "")
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+}
+
+func TestHealthHandlerControlsWorkers(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	handler := taskRunner.HealthHandler(true)
+	taskRunner.StartWorkerWithContext("health_control", noopWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("health_control")
+
+	statusCode, status := serveHealth(handler, http.MethodPost, "/workers/health_control/pause", "")
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.True(t, status.Tasks[0].Paused)
+
+	statusCode, status = serveHealth(handler, http.MethodPut, "/workers/health_control/batchSize", `{"batchSize": 4}`)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 4, status.Tasks[0].BatchSize)
+
+	statusCode, _ = serveHealth(handler, http.MethodPut, "/workers/health_control/batchSize", `{"batchSize": -1}`)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	statusCode, status = serveHealth(handler, http.MethodPost, "/workers/health_control/resume", "")
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.False(t, status.Tasks[0].Paused)
+
+	statusCode, _ = serveHealth(handler, http.MethodPost, "/workers/unknown_task/pause", "")
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
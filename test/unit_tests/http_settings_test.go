@@ -0,0 +1,181 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+var taskDefHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"name": "task"}`))
+})
+
+func getTaskDefWithSettings(httpSettings *settings.HttpSettings) error {
+	apiClient := client.NewAPIClient(nil, httpSettings)
+	apiClient.SetRetryPolicy(nil)
+	_, _, err := client.NewMetadataClient(apiClient).GetTaskDef(context.Background(), "task")
+	return err
+}
+
+func encodeCertificatePEM(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
+
+// newClientCertificate returns a self-signed client certificate, and its private key, PEM encoded.
+func newClientCertificate(t *testing.T) (*x509.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "worker"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return certificate, encodeCertificatePEM(certificate), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestTLSWithCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(taskDefHandler)
+	t.Cleanup(server.Close)
+
+	err := getTaskDefWithSettings(settings.NewHttpSettings(server.URL))
+	assert.ErrorContains(t, err, "certificate")
+
+	httpSettings := settings.NewHttpSettings(server.URL)
+	httpSettings.TLS = &settings.TLSSettings{CACertPEM: encodeCertificatePEM(server.Certificate())}
+	assert.NoError(t, getTaskDefWithSettings(httpSettings))
+}
+
+func TestMutualTLS(t *testing.T) {
+	clientCertificate, clientCertPEM, clientKeyPEM := newClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate)
+	server := httptest.NewUnstartedServer(taskDefHandler)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	httpSettings := settings.NewHttpSettings(server.URL)
+	httpSettings.TLS = &settings.TLSSettings{CACertPEM: encodeCertificatePEM(server.Certificate())}
+	assert.Error(t, getTaskDefWithSettings(httpSettings))
+
+	httpSettings.TLS.ClientCertPEM = clientCertPEM
+	httpSettings.TLS.ClientKeyPEM = clientKeyPEM
+	assert.NoError(t, getTaskDefWithSettings(httpSettings))
+}
+
+func TestTLSMinVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(taskDefHandler)
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	httpSettings := settings.NewHttpSettings(server.URL)
+	httpSettings.TLS = &settings.TLSSettings{CACertPEM: encodeCertificatePEM(server.Certificate())}
+	assert.NoError(t, getTaskDefWithSettings(httpSettings))
+
+	httpSettings.TLS.MinVersion = tls.VersionTLS13
+	assert.ErrorContains(t, getTaskDefWithSettings(httpSettings), "protocol version")
+}
+
+func TestInvalidHttpSettingsFailRequests(t *testing.T) {
+	server := httptest.NewServer(taskDefHandler)
+	t.Cleanup(server.Close)
+	httpSettings := settings.NewHttpSettings(server.URL)
+	httpSettings.TLS = &settings.TLSSettings{CACertFile: "/missing/ca.pem"}
+
+	apiClient := client.NewAPIClient(nil, httpSettings)
+	start := time.Now()
+	_, _, err := client.NewMetadataClient(apiClient).GetTaskDef(context.Background(), "task")
+	assert.True(t, errors.Is(err, client.ErrInvalidHttpSettings))
+	assert.ErrorContains(t, err, "failed to read CA certificates")
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "invalid settings should not be retried")
+}
+
+func TestHttpTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		taskDefHandler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	httpSettings := settings.NewHttpSettings(server.URL)
+	httpSettings.Timeout = 100 * time.Millisecond
+	assert.ErrorContains(t, getTaskDefWithSettings(httpSettings), "Client.Timeout exceeded")
+}
+
+func TestProxyUrl(t *testing.T) {
+	var proxiedHost atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost.Store(r.URL.Host)
+		taskDefHandler(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+	httpSettings := settings.NewHttpSettings("http://conductor.invalid/api")
+	httpSettings.ProxyUrl = proxy.URL
+	assert.NoError(t, getTaskDefWithSettings(httpSettings))
+	assert.Equal(t, "conductor.invalid", proxiedHost.Load())
+}
+
+type countingRoundTripper struct {
+	requests atomic.Int32
+}
+
+func (t *countingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestCallerSuppliedTransportAndClient(t *testing.T) {
+	server := httptest.NewServer(taskDefHandler)
+	t.Cleanup(server.Close)
+
+	transport := &countingRoundTripper{}
+	httpSettings := settings.NewHttpSettings(server.URL)
+	httpSettings.Transport = transport
+	assert.NoError(t, getTaskDefWithSettings(httpSettings))
+	assert.Equal(t, int32(1), transport.requests.Load())
+
+	clientTransport := &countingRoundTripper{}
+	httpSettings = settings.NewHttpSettings(server.URL)
+	httpSettings.Transport = transport
+	httpSettings.HttpClient = &http.Client{Transport: clientTransport}
+	assert.NoError(t, getTaskDefWithSettings(httpSettings))
+	assert.Equal(t, int32(1), clientTransport.requests.Load())
+	assert.Equal(t, int32(1), transport.requests.Load())
+}
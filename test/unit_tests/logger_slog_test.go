@@ -0,0 +1,37 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+//go:build go1.21
+
+package unit_tests
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLoggerFields(t *testing.T) {
+	output := &syncBuffer{}
+	handler := slog.NewJSONHandler(output, &slog.HandlerOptions{Level: log.LevelTrace})
+	logger := log.NewSlogLogger(slog.New(handler)).With(log.TaskTypeKey, "slog_task")
+	logger.Trace("polled", "count", 2)
+	logger.Infof("polled %d tasks", 3)
+
+	lines := output.lines()
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "polled", lines[0]["msg"])
+	assert.Equal(t, "DEBUG-4", lines[0]["level"])
+	assert.Equal(t, "slog_task", lines[0][log.TaskTypeKey])
+	assert.Equal(t, float64(2), lines[0]["count"])
+	assert.Equal(t, "polled 3 tasks", lines[1]["msg"])
+	assert.Equal(t, "slog_task", lines[1][log.TaskTypeKey])
+}
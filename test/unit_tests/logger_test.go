@@ -0,0 +1,139 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/log"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, as workers log from several goroutines.
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) lines() []map[string]interface{} {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(b.buffer.Bytes()))
+	for scanner.Scan() {
+		line := map[string]interface{}{}
+		if json.Unmarshal(scanner.Bytes(), &line) == nil {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func setLoggerForTest(t *testing.T, logger log.Logger) {
+	previous := log.GetLogger()
+	log.SetLogger(logger)
+	t.Cleanup(func() { log.SetLogger(previous) })
+}
+
+// newJSONLogrusLogger returns a logrus logger writing every level as JSON lines to output.
+func newJSONLogrusLogger(output *syncBuffer) *logrus.Logger {
+	logrusLogger := logrus.New()
+	logrusLogger.SetOutput(output)
+	logrusLogger.SetFormatter(&logrus.JSONFormatter{})
+	logrusLogger.SetLevel(logrus.TraceLevel)
+	return logrusLogger
+}
+
+func TestWorkerLogsCarryTaskFields(t *testing.T) {
+	output := &syncBuffer{}
+	setLoggerForTest(t, log.NewLogrusLogger(newJSONLogrusLogger(output)))
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "structured_logs",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+		Domain:             "blue",
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorkerWithDomain("structured_logs", func(task *model.Task) (interface{}, error) {
+		return nil, fmt.Errorf("failed on purpose")
+	}, 1, 10*time.Millisecond, "blue")
+	defer taskRunner.Shutdown("structured_logs")
+	server.waitForResults(1, 5*time.Second)
+
+	var executionLines int
+	for _, line := range output.lines() {
+		if line["msg"] != "Failed to execute task" {
+			continue
+		}
+		executionLines += 1
+		assert.Equal(t, "structured_logs", line[log.TaskTypeKey])
+		assert.Equal(t, "task-1", line[log.TaskIdKey])
+		assert.Equal(t, "workflow-1", line[log.WorkflowIdKey])
+		assert.Equal(t, "blue", line[log.DomainKey])
+		assert.Equal(t, "failed on purpose", line[log.ErrorKey])
+	}
+	assert.Equal(t, 1, executionLines)
+}
+
+func TestLogrusLoggerFields(t *testing.T) {
+	output := &syncBuffer{}
+	logger := log.NewLogrusLogger(newJSONLogrusLogger(output)).With(log.TaskTypeKey, "logrus_task")
+	logger.Info("polled", "count", 2, 3, "dangling")
+
+	lines := output.lines()
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "polled", lines[0]["msg"])
+	assert.Equal(t, "info", lines[0]["level"])
+	assert.Equal(t, "logrus_task", lines[0][log.TaskTypeKey])
+	assert.Equal(t, float64(2), lines[0]["count"])
+	assert.Equal(t, float64(3), lines[0]["!BADKEY2"])
+	assert.Equal(t, "dangling", lines[0]["!BADKEY3"])
+}
+
+func TestPrintfLoggerAppendsFields(t *testing.T) {
+	output := &syncBuffer{}
+	setLoggerForTest(t, newJSONLogrusLogger(output))
+	log.With(log.TaskTypeKey, "printf_task").Warn("Failed to poll", log.ErrorKey, fmt.Errorf("unavailable"))
+
+	lines := output.lines()
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "Failed to poll error=unavailable taskType=printf_task", lines[0]["msg"])
+	assert.Equal(t, "warning", lines[0]["level"])
+}
+
+func TestVariadicLogFunctions(t *testing.T) {
+	output := &syncBuffer{}
+	setLoggerForTest(t, newJSONLogrusLogger(output))
+	log.Error(fmt.Errorf("unavailable"))
+	log.Info("Started workflow with Id: ", 42)
+	log.Infow("Started workflow", log.WorkflowIdKey, "workflow-1")
+
+	lines := output.lines()
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "unavailable", lines[0]["msg"])
+	assert.Equal(t, "error", lines[0]["level"])
+	assert.Equal(t, "Started workflow with Id: 42", lines[1]["msg"])
+	assert.Equal(t, "Started workflow workflowId=workflow-1", lines[2]["msg"])
+}
@@ -0,0 +1,141 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// getCounterValue returns the value of the counter with the provided name and taskType label in the registry.
+func getCounterValue(t *testing.T, registry *prometheus.Registry, name string, taskType string) float64 {
+	families, err := registry.Gather()
+	assert.Nil(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == string(metrics.TASK_TYPE) && label.GetValue() == taskType {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestTaskRunnerMetricsRecordedInOwnRegistry(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "metrics_registry",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	registry := prometheus.NewRegistry()
+	recorder, err := metrics.NewPrometheusRecorder(registry)
+	assert.Nil(t, err)
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetMetricsRecorder(recorder)
+	taskRunner.StartWorker("metrics_registry", func(task *model.Task) (interface{}, error) {
+		return nil, fmt.Errorf("failed on purpose")
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("metrics_registry")
+	server.waitForResults(1, 5*time.Second)
+
+	assert.GreaterOrEqual(t, getCounterValue(t, registry, string(metrics.TASK_POLL), "metrics_registry"), float64(1))
+	assert.Equal(t, float64(1), getCounterValue(t, registry, string(metrics.TASK_EXECUTE_ERROR), "metrics_registry"))
+	_, err = metrics.NewPrometheusRecorder(registry)
+	assert.NotNil(t, err, "metrics can not be registered twice in the same registry")
+}
+
+func TestOtelRecorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	recorder, err := metrics.NewOtelRecorder(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	assert.Nil(t, err)
+	taskMetrics := metrics.NewMetrics(recorder)
+	taskMetrics.IncrementTaskPoll("otel_task")
+	taskMetrics.IncrementTaskPoll("otel_task")
+	taskMetrics.RecordTaskPollTime("otel_task", 0.5)
+	taskMetrics.RecordTaskPollTime("otel_task", 1.5)
+
+	var collected metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &collected))
+	values := map[string]float64{}
+	for _, scopeMetrics := range collected.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					taskType, _ := point.Attributes.Value("taskType")
+					values[m.Name+"/"+taskType.AsString()] = float64(point.Value)
+				}
+			case metricdata.Gauge[float64]:
+				for _, point := range data.DataPoints {
+					taskType, _ := point.Attributes.Value("taskType")
+					values[m.Name+"/"+taskType.AsString()] = point.Value
+				}
+			}
+		}
+	}
+	assert.Equal(t, float64(2), values["task_poll/otel_task"])
+	assert.Equal(t, 1.5, values["task_poll_time/otel_task"])
+}
+
+func TestMetricsServerShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	registry := prometheus.NewRegistry()
+	recorder, err := metrics.NewPrometheusRecorder(registry)
+	assert.Nil(t, err)
+	metrics.NewMetrics(recorder).IncrementTaskPoll("metrics_server")
+	server := metrics.NewMetricsServer(settings.NewMetricsSettings("/metrics", port), registry)
+	served := make(chan error, 1)
+	go func() {
+		served <- server.ListenAndServe()
+	}()
+
+	var body []byte
+	assert.Eventually(t, func() bool {
+		response, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", port))
+		if err != nil {
+			return false
+		}
+		defer response.Body.Close()
+		body, _ = io.ReadAll(response.Body)
+		return response.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, string(body), `task_poll{taskType="metrics_server"} 1`)
+
+	assert.Nil(t, server.Shutdown(context.Background()))
+	select {
+	case err := <-served:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("metrics server did not stop")
+	}
+}
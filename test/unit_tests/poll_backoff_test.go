@@ -0,0 +1,46 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollBackoffSettings(t *testing.T) {
+	taskRunner := worker.NewTaskRunner(nil, nil)
+	assert.Nil(t, taskRunner.GetPollBackoffForTask("backoff_task"))
+
+	backoff := worker.NewPollBackoff(2, time.Second)
+	taskRunner.SetPollBackoff(backoff)
+	assert.Equal(t, backoff, taskRunner.GetPollBackoffForTask("backoff_task"))
+
+	taskRunner.SetPollBackoffForTask("backoff_task", nil)
+	assert.Nil(t, taskRunner.GetPollBackoffForTask("backoff_task"))
+	assert.Equal(t, backoff, taskRunner.GetPollBackoffForTask("another_task"))
+}
+
+func TestPollBackoffOnEmptyPolls(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetPollBackoffForTask("idle_task_backoff", worker.NewPollBackoff(2, 400*time.Millisecond))
+	taskRunner.StartWorker("idle_task", TaskWorker, 1, 50*time.Millisecond)
+	taskRunner.StartWorker("idle_task_backoff", TaskWorker, 1, 50*time.Millisecond)
+
+	time.Sleep(time.Second)
+	taskRunner.Shutdown("idle_task")
+	taskRunner.Shutdown("idle_task_backoff")
+
+	assert.GreaterOrEqual(t, server.getPolls("idle_task"), 12)
+	assert.LessOrEqual(t, server.getPolls("idle_task_backoff"), 6)
+}
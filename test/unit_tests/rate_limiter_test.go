@@ -0,0 +1,38 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitGatesPolling(t *testing.T) {
+	server := newConductorServerMock(t)
+	enqueueTasks(server, "rate_limited", 40)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetRateLimitForTask("rate_limited", 10, 2))
+	taskRunner.StartWorkerWithContext("rate_limited", noopWorker, 20, 10*time.Millisecond)
+	defer taskRunner.Shutdown("rate_limited")
+
+	time.Sleep(time.Second)
+	polled := len(server.getResults())
+	assert.GreaterOrEqual(t, polled, 5)
+	assert.LessOrEqual(t, polled, 15)
+}
+
+func TestRateLimitRequiresPositiveBurst(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.NotNil(t, taskRunner.SetRateLimitForTask("rate_limited_invalid", 10, 0))
+	assert.Nil(t, taskRunner.SetRateLimitForTask("rate_limited_invalid", 0, 0))
+}
@@ -0,0 +1,120 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func noopWorker(ctx context.Context, task *model.Task) (interface{}, error) {
+	return nil, nil
+}
+
+func TestStartWorkerRegistersMissingTaskDef(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	err := taskRunner.StartWorkerWithOptions("task_def_missing", noopWorker, 1, 10*time.Millisecond, worker.WorkerOptions{
+		TaskDef: &model.TaskDef{
+			RetryCount:     3,
+			TimeoutSeconds: 60,
+			OwnerEmail:     "team@example.com",
+		},
+		Tags: []model.MetadataTag{{Key: "team", Value: "payments"}},
+	})
+	defer taskRunner.Shutdown("task_def_missing")
+	assert.Nil(t, err)
+
+	taskDef, ok := server.getTaskDef("task_def_missing")
+	assert.True(t, ok)
+	assert.Equal(t, int32(3), taskDef.RetryCount)
+	assert.Equal(t, "team@example.com", taskDef.OwnerEmail)
+	assert.Len(t, taskDef.Tags, 1)
+	assert.Equal(t, "team", taskDef.Tags[0].Key)
+	assert.Equal(t, 1, taskRunner.GetBatchSizeForTask("task_def_missing"))
+}
+
+func TestStartWorkerUpdatesDivergentTaskDef(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.putTaskDef(model.TaskDef{
+		Name:              "task_def_divergent",
+		RetryCount:        1,
+		TimeoutSeconds:    60,
+		TimeoutPolicy:     "TIME_OUT_WF",
+		OwnerEmail:        "team@example.com",
+		RetryDelaySeconds: 30,
+		Tags:              []model.TagObject{model.NewTagObject(model.MetadataTag{Key: "team", Value: "payments"})},
+	})
+	taskRunner := server.newTaskRunner()
+	err := taskRunner.StartWorkerWithOptions("task_def_divergent", noopWorker, 1, 10*time.Millisecond, worker.WorkerOptions{
+		TaskDef: &model.TaskDef{RetryCount: 5, TimeoutSeconds: 60},
+	})
+	defer taskRunner.Shutdown("task_def_divergent")
+	assert.Nil(t, err)
+
+	taskDef, _ := server.getTaskDef("task_def_divergent")
+	assert.Equal(t, int32(5), taskDef.RetryCount)
+	assert.Equal(t, 1, server.getTaskDefUpdates())
+	// fields and tags left unset are kept
+	assert.Equal(t, "TIME_OUT_WF", taskDef.TimeoutPolicy)
+	assert.Equal(t, "team@example.com", taskDef.OwnerEmail)
+	assert.Equal(t, int32(30), taskDef.RetryDelaySeconds)
+	if assert.Len(t, taskDef.Tags, 1) {
+		assert.Equal(t, "payments", taskDef.Tags[0].Value)
+	}
+}
+
+func TestStartWorkerKeepsMatchingTaskDef(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.putTaskDef(model.TaskDef{
+		Name:           "task_def_matching",
+		RetryCount:     2,
+		TimeoutSeconds: 60,
+		TimeoutPolicy:  "TIME_OUT_WF",
+		InputTemplate:  map[string]interface{}{"retries": 3},
+		Tags:           []model.TagObject{model.NewTagObject(model.MetadataTag{Key: "team", Value: "payments"})},
+	})
+	taskRunner := server.newTaskRunner()
+	err := taskRunner.StartWorkerWithOptions("task_def_matching", noopWorker, 1, 10*time.Millisecond, worker.WorkerOptions{
+		TaskDef: &model.TaskDef{
+			RetryCount:     2,
+			TimeoutSeconds: 60,
+			InputTemplate:  map[string]interface{}{"retries": 3},
+		},
+		Tags:          []model.MetadataTag{{Key: "team", Value: "payments"}},
+		StrictTaskDef: true,
+	})
+	defer taskRunner.Shutdown("task_def_matching")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, server.getTaskDefUpdates())
+}
+
+func TestStrictTaskDefFailsStartupOnDivergence(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.putTaskDef(model.TaskDef{
+		Name:           "task_def_strict",
+		RetryCount:     1,
+		TimeoutSeconds: 60,
+	})
+	taskRunner := server.newTaskRunner()
+	err := taskRunner.StartWorkerWithOptions("task_def_strict", noopWorker, 1, 10*time.Millisecond, worker.WorkerOptions{
+		TaskDef:       &model.TaskDef{RetryCount: 1, TimeoutSeconds: 120},
+		StrictTaskDef: true,
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "timeoutSeconds")
+	assert.Equal(t, 0, server.getTaskDefUpdates())
+	assert.Equal(t, 0, taskRunner.GetBatchSizeForTask("task_def_strict"))
+}
@@ -0,0 +1,110 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaseExtendedWhileWorkerRuns(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:            "lease_extension",
+		TaskId:                 "task-1",
+		WorkflowInstanceId:     "workflow-1",
+		ResponseTimeoutSeconds: 1,
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetLeaseExtensionForTask("lease_extension", true)
+	taskRunner.StartWorkerWithContext("lease_extension", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		lease := worker.GetTaskLease(ctx)
+		lease.SetOutputData(map[string]interface{}{"progress": 50})
+		lease.Log("halfway")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+		lease.Log("done")
+		return map[string]interface{}{"progress": 100}, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("lease_extension")
+
+	results := server.waitForResults(3, 5*time.Second)
+	assert.GreaterOrEqual(t, len(results), 3)
+	extension := results[0]
+	assert.Equal(t, model.InProgressTask, extension.Status)
+	assert.True(t, extension.ExtendLease)
+	assert.Equal(t, float64(50), extension.OutputData["progress"])
+	assert.Len(t, extension.Logs, 1)
+	assert.Equal(t, "halfway", extension.Logs[0].Log)
+
+	final := results[len(results)-1]
+	assert.Equal(t, model.CompletedTask, final.Status)
+	assert.False(t, final.ExtendLease)
+	assert.Equal(t, float64(100), final.OutputData["progress"])
+	assert.Len(t, final.Logs, 1)
+	assert.Equal(t, "done", final.Logs[0].Log)
+}
+
+func TestLogsOfFailedLeaseExtensionAreKept(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:            "lease_rejected",
+		TaskId:                 "task-1",
+		WorkflowInstanceId:     "workflow-1",
+		ResponseTimeoutSeconds: 1,
+	})
+	server.setUpdateStatusCode(http.StatusInternalServerError)
+	release := make(chan struct{})
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetLeaseExtensionForTask("lease_rejected", true)
+	taskRunner.StartWorkerWithContext("lease_rejected", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		lease := worker.GetTaskLease(ctx)
+		lease.Log("halfway")
+		<-release
+		lease.Log("done")
+		return map[string]interface{}{}, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("lease_rejected")
+
+	assert.Eventually(t, func() bool {
+		return server.getUpdateAttempts() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	server.setUpdateStatusCode(0)
+	close(release)
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	final := results[0]
+	assert.Equal(t, model.CompletedTask, final.Status)
+	logs := make([]string, 0, len(final.Logs))
+	for _, taskLog := range final.Logs {
+		logs = append(logs, taskLog.Log)
+	}
+	assert.Equal(t, []string{"halfway", "done"}, logs)
+}
+
+func TestLeaseNotExtendedByDefault(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.False(t, taskRunner.IsLeaseExtensionEnabledForTask("lease_default"))
+	taskRunner.SetLeaseExtension(true)
+	assert.True(t, taskRunner.IsLeaseExtensionEnabledForTask("lease_default"))
+	taskRunner.SetLeaseExtensionForTask("lease_default", false)
+	assert.False(t, taskRunner.IsLeaseExtensionEnabledForTask("lease_default"))
+}
@@ -0,0 +1,77 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskLoggerLogsAttachedToTaskResult(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "task_logger_result",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	before := time.Now().UnixMilli()
+	taskRunner.StartWorkerWithContext("task_logger_result", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		logger := worker.GetTaskLogger(ctx)
+		logger.Log("started")
+		logger.Logf("processed %d items", 3)
+		return nil, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("task_logger_result")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Len(t, results[0].Logs, 2)
+	assert.Equal(t, "started", results[0].Logs[0].Log)
+	assert.Equal(t, "processed 3 items", results[0].Logs[1].Log)
+	assert.Equal(t, "task-1", results[0].Logs[0].TaskId)
+	assert.GreaterOrEqual(t, results[0].Logs[0].CreatedTime, before)
+}
+
+func TestTaskLoggerFlushesLogsWhileRunning(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "task_logger_flush",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetTaskLogFlushInterval(50 * time.Millisecond)
+	flushed := make(chan bool)
+	taskRunner.StartWorkerWithContext("task_logger_flush", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		logger := worker.GetTaskLogger(ctx)
+		logger.Log("started")
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) && len(server.getLogs("task-1")) == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		flushed <- len(server.getLogs("task-1")) > 0
+		logger.Log("finished")
+		return nil, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("task_logger_flush")
+
+	assert.True(t, <-flushed)
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Equal(t, []string{"started"}, server.getLogs("task-1"))
+	assert.Len(t, results, 1)
+	assert.Len(t, results[0].Logs, 1)
+	assert.Equal(t, "finished", results[0].Logs[0].Log)
+}
@@ -0,0 +1,124 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFastRetryPolicy() *worker.ExponentialBackoffRetryPolicy {
+	retryPolicy := worker.NewExponentialBackoffRetryPolicy()
+	retryPolicy.InitialInterval = 10 * time.Millisecond
+	retryPolicy.MaxInterval = 20 * time.Millisecond
+	retryPolicy.MaxAttempts = 3
+	return retryPolicy
+}
+
+func TestExponentialBackoffRetryPolicy(t *testing.T) {
+	retryPolicy := worker.NewExponentialBackoffRetryPolicy()
+	retryPolicy.RandomizationFactor = 0
+	retryPolicy.MaxAttempts = 4
+
+	backoff, ok := retryPolicy.Backoff(1, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, backoff)
+	backoff, ok = retryPolicy.Backoff(3, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 1125*time.Millisecond, backoff)
+	_, ok = retryPolicy.Backoff(5, 0)
+	assert.False(t, ok)
+	_, ok = retryPolicy.Backoff(2, 5*time.Minute)
+	assert.False(t, ok)
+
+	assert.True(t, retryPolicy.IsRetryable(client.NewGenericSwaggerError(nil, "", nil, http.StatusServiceUnavailable)))
+	assert.False(t, retryPolicy.IsRetryable(client.NewGenericSwaggerError(nil, "", nil, http.StatusNotFound)))
+}
+
+func TestNonRetryableUpdateIsNotRetried(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.setUpdateStatusCode(http.StatusNotFound)
+	server.enqueue(model.Task{
+		TaskDefName:        "update_not_found",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetUpdateRetryPolicy(newFastRetryPolicy())
+	taskRunner.StartWorker("update_not_found", TaskWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("update_not_found")
+
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, 1, server.getUpdateAttempts())
+}
+
+func TestUndeliveredTaskResultIsReplayed(t *testing.T) {
+	store, err := worker.NewFileTaskResultStore(t.TempDir())
+	assert.Nil(t, err)
+	server := newConductorServerMock(t)
+	server.setUpdateStatusCode(http.StatusServiceUnavailable)
+	server.enqueue(model.Task{
+		TaskDefName:        "update_replayed",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetUpdateRetryPolicy(newFastRetryPolicy())
+	taskRunner.SetTaskResultStore(store, 50*time.Millisecond)
+	taskRunner.StartWorker("update_replayed", TaskWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("update_replayed")
+
+	assert.Eventually(t, func() bool {
+		pendingResults, _ := store.List()
+		return len(pendingResults) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, server.getUpdateAttempts(), 4)
+
+	server.setUpdateStatusCode(0)
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "task-1", results[0].TaskId)
+	assert.Equal(t, model.CompletedTask, results[0].Status)
+	assert.Eventually(t, func() bool {
+		pendingResults, err := store.List()
+		return err == nil && len(pendingResults) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileTaskResultStoreQuarantinesCorruptFiles(t *testing.T) {
+	directory := t.TempDir()
+	store, err := worker.NewFileTaskResultStore(directory)
+	assert.Nil(t, err)
+	assert.Nil(t, store.Save(worker.PendingTaskResult{
+		TaskName:   "stored_task",
+		TaskResult: &model.TaskResult{TaskId: "task-1", Status: model.CompletedTask},
+	}))
+	assert.Nil(t, os.WriteFile(filepath.Join(directory, "task-2.json"), []byte("{not json"), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(directory, "task-3.json"), []byte("{}"), 0o644))
+
+	pendingResults, err := store.List()
+	assert.Nil(t, err)
+	assert.Len(t, pendingResults, 1)
+	assert.Equal(t, "task-1", pendingResults[0].TaskResult.TaskId)
+	assert.FileExists(t, filepath.Join(directory, "task-2.json.corrupt"))
+	assert.FileExists(t, filepath.Join(directory, "task-3.json.corrupt"))
+
+	pendingResults, err = store.List()
+	assert.Nil(t, err)
+	assert.Len(t, pendingResults, 1)
+}
@@ -0,0 +1,203 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/authentication"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// authServer issues JWTs, and serves task definitions to the requests carrying one which isn't rejected.
+type authServer struct {
+	*httptest.Server
+	mutex           sync.Mutex
+	tokenLifetime   time.Duration
+	tokenStatusCode int
+	tokens          []string
+	rejectedTokens  map[string]bool
+	rejectAll       bool
+	rejectionStatus int
+	rejectionBody   string
+	apiTokens       []string
+}
+
+func newAuthServer(t *testing.T, tokenLifetime time.Duration) *authServer {
+	server := &authServer{
+		tokenLifetime:   tokenLifetime,
+		tokenStatusCode: http.StatusOK,
+		rejectedTokens:  map[string]bool{},
+		rejectionStatus: http.StatusUnauthorized,
+		rejectionBody:   `{"error": "EXPIRED_TOKEN", "message": "Token has expired"}`,
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *authServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/token" {
+		if s.tokenStatusCode != http.StatusOK {
+			w.WriteHeader(s.tokenStatusCode)
+			return
+		}
+		token := newJWT(time.Now().Add(s.tokenLifetime), len(s.tokens)+1)
+		s.tokens = append(s.tokens, token)
+		fmt.Fprintf(w, `{"token": "%s"}`, token)
+		return
+	}
+	token := r.Header.Get("X-Authorization")
+	s.apiTokens = append(s.apiTokens, token)
+	if s.rejectAll || s.rejectedTokens[token] {
+		w.WriteHeader(s.rejectionStatus)
+		w.Write([]byte(s.rejectionBody))
+		return
+	}
+	w.Write([]byte(`{"name": "task"}`))
+}
+
+func (s *authServer) rejectToken(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rejectedTokens[token] = true
+}
+
+func (s *authServer) getTokens() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.tokens...)
+}
+
+func (s *authServer) getAPITokens() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.apiTokens...)
+}
+
+func newJWT(expiry time.Time, id int) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header := encode([]byte(`{"alg": "HS256", "typ": "JWT"}`))
+	payload := encode([]byte(fmt.Sprintf(`{"jti": "%d", "exp": %d}`, id, expiry.Unix())))
+	return header + "." + payload + ".signature"
+}
+
+func newAuthenticatedMetadataClient(url string, tokenExpiration *authentication.TokenExpiration) client.MetadataClient {
+	apiClient := client.NewAPIClientWithTokenExpiration(
+		settings.NewAuthenticationSettings("key", "secret"),
+		settings.NewHttpSettings(url),
+		tokenExpiration,
+	)
+	apiClient.SetRetryPolicy(nil)
+	return client.NewMetadataClient(apiClient)
+}
+
+func TestGetTokenExpiry(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	tokenExpiry, ok := authentication.GetTokenExpiry(newJWT(expiry, 1))
+	assert.True(t, ok)
+	assert.True(t, expiry.Equal(tokenExpiry))
+
+	_, ok = authentication.GetTokenExpiry("opaque-token")
+	assert.False(t, ok)
+}
+
+func TestTokenInUseRefreshedBeforeExpiry(t *testing.T) {
+	server := newAuthServer(t, 2*time.Second)
+	tokenExpiration := authentication.NewTokenExpiration(30*time.Minute, time.Hour)
+	tokenExpiration.RefreshBefore = time.Second
+	metadataClient := newAuthenticatedMetadataClient(server.URL, tokenExpiration)
+
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(server.getTokens()) == 2 }, 3*time.Second, 50*time.Millisecond)
+
+	// the refreshed token is not used, and is left to expire
+	time.Sleep(1500 * time.Millisecond)
+	assert.Len(t, server.getTokens(), 2)
+
+	_, _, err = metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	tokens := server.getTokens()
+	apiTokens := server.getAPITokens()
+	assert.Equal(t, tokens[len(tokens)-1], apiTokens[len(apiTokens)-1])
+}
+
+func TestRejectedTokenIsRenewed(t *testing.T) {
+	server := newAuthServer(t, time.Hour)
+	metadataClient := newAuthenticatedMetadataClient(server.URL, nil)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	server.rejectToken(server.getTokens()[0])
+
+	taskDef, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Equal(t, "task", taskDef.Name)
+	tokens := server.getTokens()
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, []string{tokens[0], tokens[0], tokens[1]}, server.getAPITokens())
+}
+
+func TestRejectedTokenIsRenewedOnlyOnce(t *testing.T) {
+	server := newAuthServer(t, time.Hour)
+	server.rejectAll = true
+	metadataClient := newAuthenticatedMetadataClient(server.URL, nil)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.True(t, errors.Is(err, client.ErrUnauthorized))
+	assert.Len(t, server.getTokens(), 2)
+	assert.Len(t, server.getAPITokens(), 2)
+}
+
+func TestForbiddenRenewsTokenOnlyWhenExpired(t *testing.T) {
+	server := newAuthServer(t, time.Hour)
+	server.rejectionStatus = http.StatusForbidden
+	server.rejectionBody = `{"status": 403, "message": "Access denied"}`
+	metadataClient := newAuthenticatedMetadataClient(server.URL, nil)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	server.rejectToken(server.getTokens()[0])
+
+	_, _, err = metadataClient.GetTaskDef(context.Background(), "task")
+	assert.True(t, errors.Is(err, client.ErrForbidden))
+	var apiErr client.GenericSwaggerError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "Access denied", apiErr.Message())
+	}
+	assert.Len(t, server.getTokens(), 1)
+
+	server.mutex.Lock()
+	server.rejectionBody = `{"error": "EXPIRED_TOKEN"}`
+	server.mutex.Unlock()
+	_, _, err = metadataClient.GetTaskDef(context.Background(), "task")
+	assert.NoError(t, err)
+	assert.Len(t, server.getTokens(), 2)
+}
+
+func TestTokenRefreshFailureIsReturned(t *testing.T) {
+	server := newAuthServer(t, time.Hour)
+	server.tokenStatusCode = http.StatusInternalServerError
+	metadataClient := newAuthenticatedMetadataClient(server.URL, nil)
+	_, _, err := metadataClient.GetTaskDef(context.Background(), "task")
+	assert.ErrorContains(t, err, "failed to get authentication token")
+	assert.Empty(t, server.getAPITokens())
+}
@@ -0,0 +1,102 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setTracingForTest(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func findSpan(recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestWorkerSpanLinksBackToWorkflowStart(t *testing.T) {
+	recorder := setTracingForTest(t)
+	server := newConductorServerMock(t)
+	workflowExecutor := executor.NewWorkflowExecutor(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+
+	workflowId, err := workflowExecutor.StartWorkflow(&model.StartWorkflowRequest{
+		Name:  "traced_workflow",
+		Input: map[string]interface{}{"orderId": "order-1"},
+	})
+	assert.Nil(t, err)
+	startSpan := findSpan(recorder, "start traced_workflow")
+	if !assert.NotNil(t, startSpan) {
+		return
+	}
+	requests, bodies := server.getStartRequests()
+	assert.Len(t, bodies, 1)
+	input := bodies[0].Input.(map[string]interface{})
+	assert.Equal(t, "order-1", input["orderId"])
+	assert.Contains(t, input, tracing.TraceContextInputKey)
+	assert.Contains(t, requests[0].Header.Get("traceparent"), startSpan.SpanContext().TraceID().String())
+
+	server.enqueue(model.Task{
+		TaskDefName:        "traced_task",
+		TaskId:             "task-1",
+		WorkflowInstanceId: workflowId,
+		InputData:          map[string]interface{}{tracing.TraceContextInputKey: input[tracing.TraceContextInputKey]},
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorkerWithContext("traced_task", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		return map[string]interface{}{"done": true}, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("traced_task")
+	server.waitForResults(1, 5*time.Second)
+
+	assert.Eventually(t, func() bool {
+		return findSpan(recorder, "update traced_task") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	executeSpan := findSpan(recorder, "execute traced_task")
+	if !assert.NotNil(t, executeSpan) {
+		return
+	}
+	assert.Equal(t, startSpan.SpanContext().TraceID(), executeSpan.SpanContext().TraceID())
+	assert.Equal(t, startSpan.SpanContext().SpanID(), executeSpan.Parent().SpanID())
+	assert.NotNil(t, findSpan(recorder, "poll traced_task"))
+	assert.NotNil(t, findSpan(recorder, "HTTP POST"))
+}
+
+func TestInputWithoutSpanIsNotModified(t *testing.T) {
+	input := map[string]interface{}{"orderId": "order-1"}
+	assert.Equal(t, input, tracing.InjectIntoInput(context.Background(), input))
+	assert.Equal(t, context.Background(), tracing.ExtractFromInput(context.Background(), input))
+}
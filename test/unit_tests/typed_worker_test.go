@@ -0,0 +1,74 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+type GreetInput struct {
+	Name  string `json:"name"`
+	Times int    `json:"times"`
+}
+
+type GreetOutput struct {
+	Greeting string `json:"greeting"`
+}
+
+func GreetWorker(ctx context.Context, input GreetInput) (GreetOutput, error) {
+	return GreetOutput{
+		Greeting: fmt.Sprintf("Hello %s x%d", input.Name, input.Times),
+	}, nil
+}
+
+func TestTypedWorkerDecodesInputAndEncodesOutput(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "typed_greet",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+		InputData:          map[string]interface{}{"name": "Conductor", "times": 2},
+	})
+	taskRunner := server.newTaskRunner()
+	err := worker.StartTypedWorker(taskRunner, "typed_greet", GreetWorker, 1, 10*time.Millisecond)
+	assert.Nil(t, err)
+	defer taskRunner.Shutdown("typed_greet")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.CompletedTask, results[0].Status)
+	assert.Equal(t, map[string]interface{}{"greeting": "Hello Conductor x2"}, results[0].OutputData)
+}
+
+func TestTypedWorkerFailsTerminallyOnInvalidInput(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "typed_greet_invalid",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+		InputData:          map[string]interface{}{"name": "Conductor", "times": "twice"},
+	})
+	taskRunner := server.newTaskRunner()
+	err := worker.StartTypedWorker(taskRunner, "typed_greet_invalid", GreetWorker, 1, 10*time.Millisecond)
+	assert.Nil(t, err)
+	defer taskRunner.Shutdown("typed_greet_invalid")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.FailedWithTerminalErrorTask, results[0].Status)
+	assert.Contains(t, results[0].ReasonForIncompletion, "failed to decode input of task typed_greet_invalid into unit_tests.GreetInput")
+}
@@ -0,0 +1,135 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func enqueueTasks(server *conductorServerMock, taskName string, count int) {
+	for i := 0; i < count; i++ {
+		server.enqueue(model.Task{
+			TaskDefName:        taskName,
+			TaskId:             fmt.Sprintf("task-%d", i),
+			WorkflowInstanceId: "workflow-1",
+		})
+	}
+}
+
+func TestUpdateBatcherSendsResultsInBatches(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enableBatchUpdates()
+	enqueueTasks(server, "batched_updates", 6)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetUpdateBatching(200*time.Millisecond, 3))
+	taskRunner.StartWorkerWithContext("batched_updates", noopWorker, 6, 10*time.Millisecond)
+	defer taskRunner.Shutdown("batched_updates")
+
+	results := server.waitForResults(6, 5*time.Second)
+	assert.Len(t, results, 6)
+	assert.Equal(t, 0, server.getUpdateAttempts())
+	sizes := server.getBatchUpdateSizes()
+	// the endpoint is probed with an empty batch first
+	if assert.NotEmpty(t, sizes) {
+		assert.Equal(t, 0, sizes[0])
+	}
+	for _, size := range sizes {
+		assert.LessOrEqual(t, size, 3)
+	}
+	assert.Less(t, len(sizes), 7)
+}
+
+func TestUpdateBatcherFallsBackToIndividualUpdates(t *testing.T) {
+	server := newConductorServerMock(t)
+	enqueueTasks(server, "batched_updates_fallback", 4)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetUpdateBatching(100*time.Millisecond, 10))
+	taskRunner.StartWorkerWithContext("batched_updates_fallback", noopWorker, 4, 10*time.Millisecond)
+	defer taskRunner.Shutdown("batched_updates_fallback")
+
+	results := server.waitForResults(4, 5*time.Second)
+	assert.Len(t, results, 4)
+	assert.Equal(t, 4, server.getUpdateAttempts())
+	assert.Empty(t, server.getBatchUpdateSizes())
+}
+
+func TestUpdateBatcherFallsBackOnErrorResponses(t *testing.T) {
+	for _, statusCode := range []int{http.StatusBadRequest, http.StatusInternalServerError} {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			server := newConductorServerMock(t)
+			server.enableBatchUpdates()
+			server.setBatchUpdateStatusCode(statusCode)
+			enqueueTasks(server, "batched_updates_rejected", 4)
+			taskRunner := server.newTaskRunner()
+			assert.Nil(t, taskRunner.SetUpdateBatching(100*time.Millisecond, 10))
+			taskRunner.StartWorkerWithContext("batched_updates_rejected", noopWorker, 4, 10*time.Millisecond)
+			defer taskRunner.Shutdown("batched_updates_rejected")
+
+			results := server.waitForResults(4, 5*time.Second)
+			assert.Len(t, results, 4)
+			assert.Equal(t, 4, server.getUpdateAttempts())
+			assert.Equal(t, []int{0}, server.getBatchUpdateSizes())
+		})
+	}
+}
+
+func TestUpdateBatchingRequiresPositiveBatchSize(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.NotNil(t, taskRunner.SetUpdateBatching(time.Second, 0))
+	assert.Nil(t, taskRunner.SetUpdateBatching(0, 0))
+}
+
+// waitForBufferedUpdates starts workers for the tasks with a batching window long enough for their updates to stay
+// buffered, and waits for them to be executed.
+func waitForBufferedUpdates(t *testing.T, server *conductorServerMock, taskName string, count int) *worker.TaskRunner {
+	enqueueTasks(server, taskName, count)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetUpdateBatching(time.Minute, 10))
+	var executions atomic.Int32
+	taskRunner.StartWorkerWithContext(taskName, func(ctx context.Context, task *model.Task) (interface{}, error) {
+		executions.Add(1)
+		return map[string]interface{}{}, nil
+	}, count, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return executions.Load() == int32(count) }, 5*time.Second, 10*time.Millisecond)
+	return taskRunner
+}
+
+func TestShutdownFlushesBufferedUpdates(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enableBatchUpdates()
+	taskRunner := waitForBufferedUpdates(t, server, "batched_updates_shutdown", 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	runningWorkers, err := taskRunner.ShutdownAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, runningWorkers)
+	assert.Len(t, server.waitForResults(3, time.Second), 3)
+}
+
+func TestStoppedBatcherReleasesBufferedUpdates(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enableBatchUpdates()
+	taskRunner := waitForBufferedUpdates(t, server, "batched_updates_stopped", 3)
+	defer taskRunner.Shutdown("batched_updates_stopped")
+
+	assert.Nil(t, taskRunner.SetUpdateBatching(0, 0))
+	assert.Len(t, server.waitForResults(3, time.Second), 3)
+}
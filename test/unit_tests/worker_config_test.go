@@ -0,0 +1,136 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerConfigFromEnv(t *testing.T) {
+	t.Setenv("CONDUCTOR_WORKER_ENV_CONFIG_TASK_POLL_INTERVAL", "250")
+	t.Setenv("CONDUCTOR_WORKER_ENV_CONFIG_TASK_POLL_TIMEOUT", "1.5s")
+	t.Setenv("CONDUCTOR_WORKER_ENV_CONFIG_TASK_THREAD_COUNT", "7")
+	t.Setenv("CONDUCTOR_WORKER_ENV_CONFIG_TASK_DOMAIN", "blue")
+	t.Setenv("CONDUCTOR_WORKER_ENV_CONFIG_TASK_PAUSED", "true")
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorkerWithContext("env-config.task", noopWorker, 1, time.Second)
+	defer taskRunner.Shutdown("env-config.task")
+
+	pollInterval, _ := taskRunner.GetPollIntervalForTask("env-config.task")
+	assert.Equal(t, 250*time.Millisecond, pollInterval)
+	pollTimeout, _ := taskRunner.GetPollTimeoutForTask("env-config.task")
+	assert.Equal(t, 1500*time.Millisecond, pollTimeout)
+	assert.Equal(t, 7, taskRunner.GetBatchSizeForTask("env-config.task"))
+	assert.Equal(t, "blue", taskRunner.GetDomainForTask("env-config.task"))
+	assert.Equal(t, 0, server.getPolls("env-config.task"))
+}
+
+func TestWorkerConfigFileReloaded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workers.yaml")
+	writeFile(t, path, "workers:\n  file_config_task:\n    pollInterval: 50ms\n    threadCount: 3\n")
+	t.Setenv("CONDUCTOR_WORKER_FILE_CONFIG_TASK_DOMAIN", "green")
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetWorkerConfigFile(path, 10*time.Millisecond))
+	taskRunner.StartWorkerWithContext("file_config_task", noopWorker, 1, time.Second)
+	defer taskRunner.Shutdown("file_config_task")
+
+	pollInterval, _ := taskRunner.GetPollIntervalForTask("file_config_task")
+	assert.Equal(t, 50*time.Millisecond, pollInterval)
+	assert.Equal(t, 3, taskRunner.GetBatchSizeForTask("file_config_task"))
+	assert.Equal(t, "green", taskRunner.GetDomainForTask("file_config_task"))
+
+	writeFile(t, path, "workers:\n  file_config_task:\n    threadCount: 9\n    domain: red\n")
+	os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second))
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetBatchSizeForTask("file_config_task") == 9
+	}, 5*time.Second, 10*time.Millisecond)
+	pollInterval, _ = taskRunner.GetPollIntervalForTask("file_config_task")
+	assert.Equal(t, 50*time.Millisecond, pollInterval)
+	assert.Equal(t, "green", taskRunner.GetDomainForTask("file_config_task"))
+}
+
+func TestWorkerConfigReloadKeepsRuntimePause(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workers.yaml")
+	writeFile(t, path, "workers:\n  paused_config_task:\n    paused: false\n    threadCount: 1\n")
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	taskRunner.SetSleepOnGenericError(10 * time.Millisecond)
+	assert.Nil(t, taskRunner.SetWorkerConfigFile(path, 10*time.Millisecond))
+	taskRunner.StartWorkerWithContext("paused_config_task", noopWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("paused_config_task")
+	taskRunner.Pause("paused_config_task")
+
+	writeFile(t, path, "workers:\n  paused_config_task:\n    paused: false\n    threadCount: 2\n")
+	os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second))
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetBatchSizeForTask("paused_config_task") == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	polls := server.getPolls("paused_config_task")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, polls, server.getPolls("paused_config_task"), "an unchanged paused setting does not resume the worker")
+
+	writeFile(t, path, "workers:\n  paused_config_task:\n    paused: true\n    threadCount: 3\n")
+	os.Chtimes(path, time.Now().Add(2*time.Second), time.Now().Add(2*time.Second))
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetBatchSizeForTask("paused_config_task") == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	writeFile(t, path, "workers:\n  paused_config_task:\n    paused: false\n    threadCount: 3\n")
+	os.Chtimes(path, time.Now().Add(3*time.Second), time.Now().Add(3*time.Second))
+	assert.Eventually(t, func() bool {
+		return server.getPolls("paused_config_task") > polls
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWorkerConfigReloadKeepsRuntimeChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workers.yaml")
+	config := "workers:\n  runtime_config_task:\n    pollInterval: 50ms\n    threadCount: 1\n    domain: red\n"
+	writeFile(t, path, config+"    pollTimeout: 10ms\n")
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.Nil(t, taskRunner.SetWorkerConfigFile(path, 10*time.Millisecond))
+	taskRunner.StartWorkerWithContext("runtime_config_task", noopWorker, 1, time.Second)
+	defer taskRunner.Shutdown("runtime_config_task")
+	taskRunner.SetPollIntervalForTask("runtime_config_task", 20*time.Millisecond)
+	assert.Nil(t, taskRunner.SetBatchSize("runtime_config_task", 2))
+	assert.Nil(t, taskRunner.SetWeightedDomainsForTask("runtime_config_task", map[string]int{"blue": 1, "green": 1}))
+
+	writeFile(t, path, config+"    pollTimeout: 30ms\n")
+	os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second))
+	assert.Eventually(t, func() bool {
+		pollTimeout, _ := taskRunner.GetPollTimeoutForTask("runtime_config_task")
+		return pollTimeout == 30*time.Millisecond
+	}, 5*time.Second, 10*time.Millisecond)
+	pollInterval, _ := taskRunner.GetPollIntervalForTask("runtime_config_task")
+	assert.Equal(t, 20*time.Millisecond, pollInterval)
+	assert.Equal(t, 2, taskRunner.GetBatchSizeForTask("runtime_config_task"))
+	assert.Equal(t, []string{"blue", "green"}, taskRunner.GetDomainsForTask("runtime_config_task"))
+}
+
+func TestWorkerConfigFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workers.json")
+	writeFile(t, path, "{\"workers\": [")
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	assert.NotNil(t, taskRunner.SetWorkerConfigFile(path, 0))
+	assert.NotNil(t, taskRunner.SetWorkerConfigFile(filepath.Join(t.TempDir(), "missing.yaml"), 0))
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,224 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerContextCancelledOnResponseTimeout(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:            "context_timeout",
+		TaskId:                 "task-1",
+		WorkflowInstanceId:     "workflow-1",
+		ResponseTimeoutSeconds: 1,
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorkerWithContext("context_timeout", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("context_timeout")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "task-1", results[0].TaskId)
+	assert.Equal(t, model.FailedTask, results[0].Status)
+	assert.Contains(t, results[0].ReasonForIncompletion, "exceeded its timeout of 1s")
+}
+
+func TestWorkerSucceedingAfterResponseTimeoutIsReported(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:            "legacy_timeout",
+		TaskId:                 "task-1",
+		WorkflowInstanceId:     "workflow-1",
+		ResponseTimeoutSeconds: 1,
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorker("legacy_timeout", func(task *model.Task) (interface{}, error) {
+		time.Sleep(1200 * time.Millisecond)
+		return map[string]interface{}{"done": true}, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("legacy_timeout")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.CompletedTask, results[0].Status)
+	assert.Equal(t, true, results[0].OutputData["done"])
+}
+
+func TestWorkerContextCancelledOnShutdown(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "context_shutdown",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	started := make(chan struct{})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorkerWithContext("context_shutdown", func(ctx context.Context, task *model.Task) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, 1, 10*time.Millisecond)
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker was not executed")
+	}
+	taskRunner.Shutdown("context_shutdown")
+	taskRunner.WaitWorkers()
+
+	results := server.getResults()
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.FailedTask, results[0].Status)
+	assert.Equal(t, context.Canceled.Error(), results[0].ReasonForIncompletion)
+}
+
+func TestShutdownAllDrainsRunningWorkers(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "shutdown_all_drain",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	started := make(chan struct{})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorker("shutdown_all_drain", func(task *model.Task) (interface{}, error) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		return map[string]interface{}{"done": true}, nil
+	}, 1, 10*time.Millisecond)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stillRunning, err := taskRunner.ShutdownAll(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, stillRunning)
+	assert.Equal(t, 0, taskRunner.GetBatchSizeForTask("shutdown_all_drain"))
+
+	results := server.getResults()
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.CompletedTask, results[0].Status)
+}
+
+func TestShutdownAllReturnsRunningWorkersOnDeadline(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "shutdown_all_deadline",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorker("shutdown_all_deadline", func(task *model.Task) (interface{}, error) {
+		close(started)
+		<-release
+		return nil, nil
+	}, 1, 10*time.Millisecond)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stillRunning, err := taskRunner.ShutdownAll(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, map[string]int{"shutdown_all_deadline": 1}, stillRunning)
+}
+
+func TestShutdownAllHandsBackTasksPolledWhileShuttingDown(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "shutdown_all_in_flight_poll",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	polling := make(chan struct{}, 1)
+	release := make(chan struct{})
+	blockingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/tasks/poll/batch/") {
+			select {
+			case polling <- struct{}{}:
+			default:
+			}
+			<-release
+		}
+		server.handle(w, r)
+	}))
+	defer blockingServer.Close()
+	var executed atomic.Bool
+	taskRunner := worker.NewTaskRunnerWithApiClient(client.NewAPIClient(nil, settings.NewHttpSettings(blockingServer.URL)))
+	taskRunner.StartWorker("shutdown_all_in_flight_poll", func(task *model.Task) (interface{}, error) {
+		executed.Store(true)
+		return map[string]interface{}{}, nil
+	}, 1, 10*time.Millisecond)
+
+	select {
+	case <-polling:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not poll")
+	}
+	shutdown := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := taskRunner.ShutdownAll(ctx)
+		shutdown <- err
+	}()
+	assert.Eventually(t, func() bool {
+		return taskRunner.GetBatchSizeForTask("shutdown_all_in_flight_poll") == 0
+	}, 5*time.Second, time.Millisecond)
+	close(release)
+
+	assert.Nil(t, <-shutdown)
+	assert.False(t, executed.Load(), "tasks polled while shutting down are not executed")
+	results := server.getResults()
+	assert.Len(t, results, 1)
+	assert.Equal(t, "task-1", results[0].TaskId)
+	assert.Equal(t, model.InProgressTask, results[0].Status)
+	assert.Equal(t, int64(0), results[0].CallbackAfterSeconds)
+
+	otherTaskRunner := server.newTaskRunner()
+	otherTaskRunner.StartWorker("shutdown_all_in_flight_poll", TaskWorker, 1, 10*time.Millisecond)
+	defer otherTaskRunner.Shutdown("shutdown_all_in_flight_poll")
+	results = server.waitForResults(2, 5*time.Second)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "task-1", results[1].TaskId)
+	assert.Equal(t, model.CompletedTask, results[1].Status)
+}
+
+func TestStartWorkerAfterShutdownAllFails(t *testing.T) {
+	server := newConductorServerMock(t)
+	taskRunner := server.newTaskRunner()
+	_, err := taskRunner.ShutdownAll(context.Background())
+	assert.Nil(t, err)
+
+	err = taskRunner.StartWorkerWithContext("started_after_shutdown", noopWorker, 1, 10*time.Millisecond)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, taskRunner.GetBatchSizeForTask("started_after_shutdown"))
+	assert.Equal(t, 0, server.getPolls("started_after_shutdown"))
+}
@@ -0,0 +1,89 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptorsRunAroundExecuteAndUpdate(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "intercepted_task",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	var mutex sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls = append(calls, call)
+	}
+	taskRunner := server.newTaskRunner()
+	taskRunner.AddExecuteInterceptor(func(ctx context.Context, task *model.Task, next worker.ExecuteHandler) *model.TaskResult {
+		record("global execute")
+		taskResult := next(ctx, task)
+		taskResult.OutputData["intercepted"] = true
+		return taskResult
+	})
+	taskRunner.AddExecuteInterceptorForTask("intercepted_task", func(ctx context.Context, task *model.Task, next worker.ExecuteHandler) *model.TaskResult {
+		record("task execute")
+		return next(ctx, task)
+	})
+	taskRunner.AddUpdateInterceptor(func(ctx context.Context, task *model.Task, taskResult *model.TaskResult, next worker.UpdateHandler) error {
+		record("global update " + string(taskResult.Status))
+		return next(ctx, task, taskResult)
+	})
+	taskRunner.StartWorker("intercepted_task", TaskWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("intercepted_task")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, map[string]interface{}{"zip": "10121", "intercepted": true}, results[0].OutputData)
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{"global execute", "task execute", "global update COMPLETED"}, calls)
+}
+
+func TestExecuteInterceptorShortCircuits(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "short_circuited_task",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	executed := false
+	taskRunner := server.newTaskRunner()
+	taskRunner.AddExecuteInterceptorForTask("short_circuited_task", func(ctx context.Context, task *model.Task, next worker.ExecuteHandler) *model.TaskResult {
+		taskResult := model.NewTaskResultFromTask(task)
+		taskResult.Status = model.FailedWithTerminalErrorTask
+		taskResult.ReasonForIncompletion = "invalid input"
+		return taskResult
+	})
+	taskRunner.StartWorker("short_circuited_task", func(task *model.Task) (interface{}, error) {
+		executed = true
+		return nil, nil
+	}, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("short_circuited_task")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.FailedWithTerminalErrorTask, results[0].Status)
+	assert.Equal(t, "invalid input", results[0].ReasonForIncompletion)
+	assert.False(t, executed)
+}
@@ -0,0 +1,84 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func PanickingWorker(task *model.Task) (interface{}, error) {
+	panic("something went wrong")
+}
+
+func TestPanickingWorkerIsReportedAsFailed(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "panicking_task",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.StartWorker("panicking_task", PanickingWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("panicking_task")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.FailedTask, results[0].Status)
+	assert.Equal(t, "worker panicked: something went wrong", results[0].ReasonForIncompletion)
+	assert.Len(t, results[0].Logs, 1)
+	assert.Equal(t, "task-1", results[0].Logs[0].TaskId)
+	assert.Contains(t, results[0].Logs[0].Log, "PanickingWorker")
+}
+
+func TestPanickingWorkerWithTerminalStatus(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "panicking_task_terminal",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	assert.NotNil(t, taskRunner.SetPanicTaskResultStatus(model.CompletedTask))
+	assert.Nil(t, taskRunner.SetPanicTaskResultStatus(model.FailedWithTerminalErrorTask))
+	taskRunner.StartWorker("panicking_task_terminal", PanickingWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("panicking_task_terminal")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.FailedWithTerminalErrorTask, results[0].Status)
+}
+
+func TestPanickingExecuteInterceptorIsReportedAsFailed(t *testing.T) {
+	server := newConductorServerMock(t)
+	server.enqueue(model.Task{
+		TaskDefName:        "panicking_interceptor_task",
+		TaskId:             "task-1",
+		WorkflowInstanceId: "workflow-1",
+	})
+	taskRunner := server.newTaskRunner()
+	taskRunner.AddExecuteInterceptorForTask("panicking_interceptor_task", func(ctx context.Context, task *model.Task, next worker.ExecuteHandler) *model.TaskResult {
+		panic("interceptor went wrong")
+	})
+	taskRunner.StartWorkerWithContext("panicking_interceptor_task", noopWorker, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown("panicking_interceptor_task")
+
+	results := server.waitForResults(1, 5*time.Second)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.FailedTask, results[0].Status)
+	assert.Equal(t, "worker panicked: interceptor went wrong", results[0].ReasonForIncompletion)
+	assert.Len(t, results[0].Logs, 1)
+	assert.Equal(t, "task-1", results[0].Logs[0].TaskId)
+}